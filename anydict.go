@@ -0,0 +1,423 @@
+/*
+Collection Library for Go
+Dictionary type without the comparable constraint on values
+*/
+package collection
+
+import "strings"
+
+/*
+Dictionary of values of any type, unordered set of key-value pairs.
+Unlike Dict, the values do not have to be comparable (e.g. slices, maps or functions),
+so the equality-based methods are replaced by their variants with a custom predicate.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+*/
+type AnyDict[K comparable, V any] interface {
+	Collection
+
+	/*
+		Acquires the value of the dictionary.
+
+		Returns:
+		  - inner map of the dictionary.
+	*/
+	getVal() map[K]V
+
+	/*
+		Asserts that the dictionary is initialized.
+	*/
+	assert()
+
+	/*
+		Panics if the given key does not exist.
+
+		Parameters:
+		  - key - key to check.
+	*/
+	checkKey(key K)
+
+	/*
+		Sets the value of the field of the dictionary.
+		If the key already exists, the value is overwritten, if not, a new field is created.
+
+		Parameters:
+		  - key - key to set,
+		  - value - new value to be set.
+
+		Returns:
+		  - updated dictionary.
+	*/
+	Set(key K, value V) AnyDict[K, V]
+
+	/*
+		Deletes the fields with given keys.
+
+		Parameters:
+		  - keys... - any amount of keys to delete.
+
+		Returns:
+		  - updated dictionary.
+	*/
+	Unset(keys ...K) AnyDict[K, V]
+
+	/*
+		Deletes all fields in the dictionary.
+
+		Returns:
+		  - updated dictionary.
+	*/
+	Clear() AnyDict[K, V]
+
+	/*
+		Acquires the value under the specified key of the dictionary.
+
+		Parameters:
+		  - key - key of the field to get.
+
+		Returns:
+		  - corresponding value.
+	*/
+	Get(key K) V
+
+	/*
+		Serializes the dictionary.
+		If only compatible types are used, the output will be a valid JSON.
+		Can be called recursively.
+
+		Returns:
+		  - string representing the serialized dictionary.
+	*/
+	String() string
+
+	/*
+		Converts the dictionary into a Go map.
+
+		Returns:
+		  - map.
+	*/
+	GoMap() map[K]V
+
+	/*
+		Convers the dictionary to a list of its keys.
+
+		Returns:
+		  - list of keys of the dictionary.
+	*/
+	Keys() List[K]
+
+	/*
+		Convers the dictionary to a list of its values.
+
+		Returns:
+		  - list of values of the dictionary.
+	*/
+	Values() AnyList[V]
+
+	/*
+		Creates a copy of the dictionary.
+
+		Returns:
+		  - copied dictionary.
+	*/
+	Clone() AnyDict[K, V]
+
+	/*
+		Gives a number of fields in the dictionary.
+
+		Returns:
+		  - number of fields.
+	*/
+	Count() int
+
+	/*
+		Checks whether the dictionary is empty.
+
+		Returns:
+		  - true if the dictionary is empty, false otherwise.
+	*/
+	Empty() bool
+
+	/*
+		Checks if the content of the dictionary is equal to the content of another dictionary.
+		The values are compared by a given function.
+
+		Parameters:
+		  - another - a dictionary to compare with,
+		  - function - anonymous function comparing two values.
+
+		Returns:
+		  - true if the dictionaries are equal, false otherwise.
+	*/
+	EqualsFunc(another AnyDict[K, V], function func(a V, b V) bool) bool
+
+	/*
+		Checks if the dictionary contains a field with a value satisfying a condition.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - true if the dictionary contains such value, false otherwise.
+	*/
+	ContainsFunc(function func(v V) bool) bool
+
+	/*
+		Gives a key containing a value satisfying a condition.
+		If multiple keys satisfy the condition, any of them is returned.
+		Panics if there is no such key.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - key for the value.
+	*/
+	KeyOfFunc(function func(v V) bool) K
+
+	/*
+		Checks if a given key exists within the dictionary.
+
+		Parameters:
+		  - key - the key to check.
+
+		Returns:
+		  - true if the key exists, false otherwise.
+	*/
+	KeyExists(key K) bool
+
+	/*
+		Executes a given function over an every field of the dictionary.
+		The function has two parameters: key of the current field and its value.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged dictionary.
+	*/
+	ForEach(function func(k K, v V)) AnyDict[K, V]
+
+	/*
+		Copies the dictionary and modifies each field by a given mapping function.
+		The resulting field has to be of a same type as the original one.
+		The function has two parameters: key of the current field and its value.
+		The old dictionary remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new dictionary.
+	*/
+	Map(function func(k K, v V) V) AnyDict[K, V]
+}
+
+/*
+Dictionary of values of any type, a reference type. Contains a map of key-value pairs.
+
+Implements:
+  - AnyDict.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+*/
+type mapAnyDict[K comparable, V any] struct {
+	val map[K]V
+}
+
+/*
+Dictionary constructor.
+Creates a new dictionary of values of any type.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+
+Returns:
+  - pointer to the created dictionary.
+*/
+func NewAnyDict[K comparable, V any]() AnyDict[K, V] {
+	return &mapAnyDict[K, V]{make(map[K]V)}
+}
+
+/*
+Dictionary constructor.
+Converts a map with values of any type to a dictionary.
+
+Parameters:
+  - goMap - original map.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+
+Returns:
+  - pointer to the created dictionary.
+*/
+func NewAnyDictFrom[K comparable, V any](goMap map[K]V) AnyDict[K, V] {
+	return &mapAnyDict[K, V]{goMap}
+}
+
+func (ego *mapAnyDict[K, V]) serialize(b *strings.Builder) {
+	b.Grow(8 * len(ego.getVal()))
+	b.WriteByte('{')
+	first := true
+	for key, value := range ego.getVal() {
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		writeString(b, key)
+		b.WriteByte(':')
+		writeString(b, value)
+	}
+	b.WriteByte('}')
+}
+
+func (ego *mapAnyDict[K, V]) getVal() map[K]V {
+	return ego.val
+}
+
+func (ego *mapAnyDict[K, V]) assert() {
+	if ego == nil || ego.getVal() == nil {
+		panic(errDictNotInitialized)
+	}
+}
+
+func (ego *mapAnyDict[K, V]) checkKey(key K) {
+	if !ego.KeyExists(key) {
+		panic(ErrKeyNotFound{key})
+	}
+}
+
+func (ego *mapAnyDict[K, V]) Set(key K, value V) AnyDict[K, V] {
+	ego.assert()
+	ego.getVal()[key] = value
+	return ego
+}
+
+func (ego *mapAnyDict[K, V]) Unset(keys ...K) AnyDict[K, V] {
+	ego.assert()
+	for _, key := range keys {
+		ego.checkKey(key)
+		delete(ego.getVal(), key)
+	}
+	return ego
+}
+
+func (ego *mapAnyDict[K, V]) Clear() AnyDict[K, V] {
+	ego.assert()
+	ego.val = make(map[K]V)
+	return ego
+}
+
+func (ego *mapAnyDict[K, V]) Get(key K) V {
+	ego.assert()
+	ego.checkKey(key)
+	return ego.getVal()[key]
+}
+
+func (ego *mapAnyDict[K, V]) String() string {
+	var b strings.Builder
+	ego.serialize(&b)
+	return b.String()
+}
+
+func (ego *mapAnyDict[K, V]) GoMap() map[K]V {
+	ego.assert()
+	return ego.getVal()
+}
+
+func (ego *mapAnyDict[K, V]) Keys() List[K] {
+	keys := &sliceList[K]{val: make([]K, 0, len(ego.getVal()))}
+	for key := range ego.getVal() {
+		keys.Add(key)
+	}
+	return keys
+}
+
+func (ego *mapAnyDict[K, V]) Values() AnyList[V] {
+	values := &sliceAnyList[V]{make([]V, 0, len(ego.getVal()))}
+	for _, value := range ego.getVal() {
+		values.Add(value)
+	}
+	return values
+}
+
+func (ego *mapAnyDict[K, V]) Clone() AnyDict[K, V] {
+	ego.assert()
+	result := &mapAnyDict[K, V]{make(map[K]V, len(ego.getVal()))}
+	for key, value := range ego.getVal() {
+		result.Set(key, value)
+	}
+	return result
+}
+
+func (ego *mapAnyDict[K, V]) Count() int {
+	ego.assert()
+	return len(ego.getVal())
+}
+
+func (ego *mapAnyDict[K, V]) Empty() bool {
+	return ego.Count() == 0
+}
+
+func (ego *mapAnyDict[K, V]) EqualsFunc(another AnyDict[K, V], function func(V, V) bool) bool {
+	if ego.Count() != another.Count() {
+		return false
+	}
+	for key, value := range ego.getVal() {
+		item, ok := another.getVal()[key]
+		if !ok || !function(value, item) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ego *mapAnyDict[K, V]) ContainsFunc(function func(V) bool) bool {
+	ego.assert()
+	for _, item := range ego.getVal() {
+		if function(item) {
+			return true
+		}
+	}
+	return false
+}
+
+func (ego *mapAnyDict[K, V]) KeyOfFunc(function func(V) bool) K {
+	ego.assert()
+	for key, item := range ego.getVal() {
+		if function(item) {
+			return key
+		}
+	}
+	panic(ErrNoMatch)
+}
+
+func (ego *mapAnyDict[K, V]) KeyExists(key K) bool {
+	ego.assert()
+	_, ok := ego.getVal()[key]
+	return ok
+}
+
+func (ego *mapAnyDict[K, V]) ForEach(function func(K, V)) AnyDict[K, V] {
+	ego.assert()
+	for key, item := range ego.getVal() {
+		function(key, item)
+	}
+	return ego
+}
+
+func (ego *mapAnyDict[K, V]) Map(function func(K, V) V) AnyDict[K, V] {
+	ego.assert()
+	result := &mapAnyDict[K, V]{make(map[K]V, len(ego.getVal()))}
+	for key, item := range ego.getVal() {
+		result.Set(key, function(key, item))
+	}
+	return result
+}
@@ -0,0 +1,574 @@
+/*
+Collection Library for Go
+List type without the comparable constraint
+*/
+package collection
+
+import (
+	"sort"
+	"strings"
+)
+
+/*
+List of elements of any type, an ordered sequence of elements.
+Unlike List, the elements do not have to be comparable (e.g. slices, maps or functions),
+so the equality-based methods are replaced by their variants with a custom predicate.
+
+Type parameters:
+  - T - type of list elements.
+*/
+type AnyList[T any] interface {
+	Collection
+
+	/*
+		Acquires the value of the list.
+
+		Returns:
+		  - inner slice of the list.
+	*/
+	getVal() []T
+
+	/*
+		Asserts that the list is initialized.
+	*/
+	assert()
+
+	/*
+		Panics if the index is out of range.
+	*/
+	indexCheck(index int)
+
+	/*
+		Inserts new elements at the end of the list.
+
+		Parameters:
+		  - values... - any amount of elements to add.
+
+		Returns:
+		  - updated list.
+	*/
+	Add(values ...T) AnyList[T]
+
+	/*
+		Inserts a new element at the specified position in the list.
+
+		Parameters:
+		  - index - position where the element should be inserted,
+		  - value - element to insert.
+
+		Returns:
+		  - updated list.
+	*/
+	Insert(index int, value T) AnyList[T]
+
+	/*
+		Replaces an existing element of the list with a new one.
+
+		Parameters:
+		  - index - position of the element which should be replaced,
+		  - value - new element.
+
+		Returns:
+		  - updated list.
+	*/
+	Replace(index int, value T) AnyList[T]
+
+	/*
+		Deletes the elements at the specified positions in the list.
+
+		Parameters:
+		  - indexes... - any amount of positions of the elements to delete.
+
+		Returns:
+		  - updated list.
+	*/
+	Delete(indexes ...int) AnyList[T]
+
+	/*
+		Deletes the last element in the list and returns it.
+
+		Returns:
+		  - popped element.
+	*/
+	Pop() T
+
+	/*
+		Deletes all elements in the list.
+
+		Returns:
+		  - updated list.
+	*/
+	Clear() AnyList[T]
+
+	/*
+		Acquires the element at the specified position in the list.
+
+		Parameters:
+		  - index - position of the element to get.
+
+		Returns:
+		  - corresponding value.
+	*/
+	Get(index int) T
+
+	/*
+		Serializes the list.
+		If only compatible types are used, the output will be a valid JSON.
+		Can be called recursively.
+
+		Returns:
+		  - string representing serialized list.
+	*/
+	String() string
+
+	/*
+		Converts the list into a Go slice.
+		The slice is a reference.
+
+		Returns:
+		  - slice.
+	*/
+	GoSlice() []T
+
+	/*
+		Creates a copy of the list.
+
+		Returns:
+		  - copied list.
+	*/
+	Clone() AnyList[T]
+
+	/*
+		Gives a number of elements in the list.
+
+		Returns:
+		  - number of elements.
+	*/
+	Count() int
+
+	/*
+		Checks whether the list is empty.
+
+		Returns:
+		  - true if the list is empty, false otherwise.
+	*/
+	Empty() bool
+
+	/*
+		Checks if the content of the list is equal to the content of another list.
+		The elements are compared by a given function.
+
+		Parameters:
+		  - another - a list to compare with,
+		  - function - anonymous function comparing two elements.
+
+		Returns:
+		  - true if the lists are equal, false otherwise.
+	*/
+	EqualsFunc(another AnyList[T], function func(a T, b T) bool) bool
+
+	/*
+		Creates a new list containing all elements of the old list and other lists.
+		The new list never shares memory with any of the lists, so all of them remain unchanged.
+		Nil lists are treated as empty.
+
+		Parameters:
+		  - others... - any amount of lists to append.
+
+		Returns:
+		  - new list.
+	*/
+	Concat(others ...AnyList[T]) AnyList[T]
+
+	/*
+		Creates a new list containing the elements from the starting index (including) to the ending index (excluding).
+		Negative indexes of both kinds are counted from the end of the list.
+		If the ending index is zero, it is set to the length of the list.
+		After the conversion, starting index cannot be higher than the ending index.
+
+		Parameters:
+		  - start - starting index,
+		  - end - ending index.
+
+		Returns:
+		  - created sub list.
+	*/
+	SubList(start int, end int) AnyList[T]
+
+	/*
+		Checks if the list contains an element satisfying a condition.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - true if the list contains such element, false otherwise.
+	*/
+	ContainsFunc(function func(x T) bool) bool
+
+	/*
+		Gives a position of the first element satisfying a condition.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - index of the element (-1 if the list does not contain such element).
+	*/
+	IndexOfFunc(function func(x T) bool) int
+
+	/*
+		Reverses the order of elements in the list.
+
+		Returns:
+		  - updated list.
+	*/
+	Reverse() AnyList[T]
+
+	/*
+		Executes a given function over an every element of the list.
+		The function has one parameter, the current element.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged list.
+	*/
+	ForEach(function func(x T)) AnyList[T]
+
+	/*
+		Copies the list and modifies each element by a given mapping function.
+		The resulting element has to be of a same type as the original one.
+		The function has one parameter, the current element.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	Map(function func(x T) T) AnyList[T]
+
+	/*
+		Reduces all elements of the list into a single value.
+		The result has to be of the same type as the elements of the list.
+		The function has two parameters: value returned by the previous iteration and value of the current element.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - computed value.
+	*/
+	Reduce(initial T, function func(res T, x T) T) T
+
+	/*
+		Creates a new list containing elements of the old one satisfying a condition.
+		The function has one parameter, the current element, and returns bool.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - filtered list.
+	*/
+	Filter(function func(x T) bool) AnyList[T]
+
+	/*
+		Sorts the elements in the list by a given comparison function.
+		The function returns a negative number if a < b, a positive number if a > b and zero if they are equal.
+		The sort is stable, equal elements keep their original order.
+
+		Parameters:
+		  - function - anonymous function comparing two elements.
+
+		Returns:
+		  - updated list.
+	*/
+	SortFunc(function func(a T, b T) int) AnyList[T]
+}
+
+/*
+List of elements of any type, a reference type. Contains a slice of elements.
+
+Implements:
+  - AnyList.
+
+Type parameters:
+  - T - type of list elements.
+*/
+type sliceAnyList[T any] struct {
+	val []T
+}
+
+/*
+List constructor.
+Creates a new list of elements of any type.
+
+Parameters:
+  - values... - any amount of initial elements.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - pointer to the created list.
+*/
+func NewAnyList[T any](values ...T) AnyList[T] {
+	ego := sliceAnyList[T]{make([]T, 0, len(values))}
+	ego.Add(values...)
+	return &ego
+}
+
+/*
+List constructor.
+Converts a slice of elements of any type to a list.
+
+Parameters:
+  - goSlice - original slice.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - pointer to the created list.
+*/
+func NewAnyListFrom[T any](goSlice []T) AnyList[T] {
+	return &sliceAnyList[T]{goSlice}
+}
+
+func (ego *sliceAnyList[T]) serialize(b *strings.Builder) {
+	b.Grow(4 * len(ego.getVal()))
+	b.WriteByte('[')
+	for i, value := range ego.getVal() {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeString(b, value)
+	}
+	b.WriteByte(']')
+}
+
+func (ego *sliceAnyList[T]) getVal() []T {
+	return ego.val
+}
+
+func (ego *sliceAnyList[T]) assert() {
+	if ego == nil || ego.getVal() == nil {
+		panic(errListNotInitialized)
+	}
+}
+
+func (ego *sliceAnyList[T]) indexCheck(index int) {
+	if index < 0 || index >= ego.Count() {
+		panic(ErrIndexOutOfRange{index, ego.Count()})
+	}
+}
+
+func (ego *sliceAnyList[T]) Add(values ...T) AnyList[T] {
+	ego.assert()
+	ego.val = append(ego.getVal(), values...)
+	return ego
+}
+
+func (ego *sliceAnyList[T]) Insert(index int, value T) AnyList[T] {
+	ego.assert()
+	if index == ego.Count() {
+		return ego.Add(value)
+	}
+	ego.indexCheck(index)
+	ego.val = append(ego.getVal()[:index+1], ego.getVal()[index:]...)
+	ego.getVal()[index] = value
+	return ego
+}
+
+func (ego *sliceAnyList[T]) Replace(index int, value T) AnyList[T] {
+	ego.assert()
+	ego.indexCheck(index)
+	ego.getVal()[index] = value
+	return ego
+}
+
+func (ego *sliceAnyList[T]) Delete(indexes ...int) AnyList[T] {
+	ego.assert()
+	if len(indexes) > 1 {
+		sort.Ints(indexes)
+	}
+	for i := len(indexes) - 1; i >= 0; i-- {
+		index := indexes[i]
+		ego.indexCheck(index)
+		ego.val = append(ego.getVal()[:index], ego.getVal()[index+1:]...)
+	}
+	return ego
+}
+
+func (ego *sliceAnyList[T]) Pop() T {
+	count := ego.Count()
+	if count == 0 {
+		panic(errPopEmpty)
+	}
+	elem := ego.getVal()[count-1]
+	ego.Delete(count - 1)
+	return elem
+}
+
+func (ego *sliceAnyList[T]) Clear() AnyList[T] {
+	ego.assert()
+	ego.val = make([]T, 0)
+	return ego
+}
+
+func (ego *sliceAnyList[T]) Get(index int) T {
+	ego.assert()
+	ego.indexCheck(index)
+	return ego.getVal()[index]
+}
+
+func (ego *sliceAnyList[T]) String() string {
+	var b strings.Builder
+	ego.serialize(&b)
+	return b.String()
+}
+
+func (ego *sliceAnyList[T]) GoSlice() []T {
+	ego.assert()
+	return ego.getVal()
+}
+
+func (ego *sliceAnyList[T]) Clone() AnyList[T] {
+	ego.assert()
+	return NewAnyList(ego.getVal()...)
+}
+
+func (ego *sliceAnyList[T]) Count() int {
+	ego.assert()
+	return len(ego.getVal())
+}
+
+func (ego *sliceAnyList[T]) Empty() bool {
+	return ego.Count() == 0
+}
+
+func (ego *sliceAnyList[T]) EqualsFunc(another AnyList[T], function func(T, T) bool) bool {
+	if ego.Count() != another.Count() {
+		return false
+	}
+	for i := range ego.getVal() {
+		if !function(ego.getVal()[i], another.getVal()[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ego *sliceAnyList[T]) Concat(others ...AnyList[T]) AnyList[T] {
+	ego.assert()
+	count := ego.Count()
+	for _, another := range others {
+		if another != nil {
+			count += len(another.getVal())
+		}
+	}
+	result := make([]T, 0, count)
+	result = append(result, ego.getVal()...)
+	for _, another := range others {
+		if another != nil {
+			result = append(result, another.getVal()...)
+		}
+	}
+	return &sliceAnyList[T]{result}
+}
+
+func (ego *sliceAnyList[T]) SubList(start int, end int) AnyList[T] {
+	ego.assert()
+	if start > ego.Count() || start < -ego.Count() {
+		panic(ErrIndexOutOfRange{start, ego.Count()})
+	}
+	if end > ego.Count() || end < -ego.Count() {
+		panic(ErrIndexOutOfRange{end, ego.Count()})
+	}
+	if start < 0 {
+		start = ego.Count() + start
+	}
+	if end <= 0 {
+		end = ego.Count() + end
+	}
+	if start > end {
+		panic(ErrInvalidRange{start, end})
+	}
+	list := &sliceAnyList[T]{make([]T, end-start)}
+	copy(list.getVal(), ego.getVal()[start:end])
+	return list
+}
+
+func (ego *sliceAnyList[T]) ContainsFunc(function func(T) bool) bool {
+	return ego.IndexOfFunc(function) >= 0
+}
+
+func (ego *sliceAnyList[T]) IndexOfFunc(function func(T) bool) int {
+	ego.assert()
+	for i, item := range ego.getVal() {
+		if function(item) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (ego *sliceAnyList[T]) Reverse() AnyList[T] {
+	ego.assert()
+	for i := ego.Count()/2 - 1; i >= 0; i-- {
+		opp := ego.Count() - 1 - i
+		ego.getVal()[i], ego.getVal()[opp] = ego.getVal()[opp], ego.getVal()[i]
+	}
+	return ego
+}
+
+func (ego *sliceAnyList[T]) ForEach(function func(T)) AnyList[T] {
+	ego.assert()
+	for _, item := range ego.getVal() {
+		function(item)
+	}
+	return ego
+}
+
+func (ego *sliceAnyList[T]) Map(function func(T) T) AnyList[T] {
+	ego.assert()
+	result := &sliceAnyList[T]{make([]T, 0, ego.Count())}
+	for _, item := range ego.getVal() {
+		result.Add(function(item))
+	}
+	return result
+}
+
+func (ego *sliceAnyList[T]) Reduce(initial T, function func(T, T) T) T {
+	ego.assert()
+	result := initial
+	for _, item := range ego.getVal() {
+		result = function(result, item)
+	}
+	return result
+}
+
+func (ego *sliceAnyList[T]) Filter(function func(T) bool) AnyList[T] {
+	ego.assert()
+	result := NewAnyList[T]()
+	for _, item := range ego.getVal() {
+		if function(item) {
+			result.Add(item)
+		}
+	}
+	return result
+}
+
+func (ego *sliceAnyList[T]) SortFunc(function func(T, T) int) AnyList[T] {
+	ego.assert()
+	sort.SliceStable(ego.getVal(), func(i, j int) bool {
+		return function(ego.getVal()[i], ego.getVal()[j]) < 0
+	})
+	return ego
+}
@@ -0,0 +1,220 @@
+/*
+Collection Library for Go
+Builder types
+*/
+package collection
+
+/*
+List builder, a tool for a fluent conditional construction of lists.
+
+Type parameters:
+  - T - type of list elements.
+*/
+type ListBuilder[T comparable] interface {
+
+	/*
+		Adds new elements to the built list.
+
+		Parameters:
+		  - values... - any amount of elements to add.
+
+		Returns:
+		  - updated builder.
+	*/
+	Add(values ...T) ListBuilder[T]
+
+	/*
+		Adds new elements to the built list if a condition holds.
+
+		Parameters:
+		  - condition - whether the elements should be added,
+		  - values... - any amount of elements to add.
+
+		Returns:
+		  - updated builder.
+	*/
+	AddIf(condition bool, values ...T) ListBuilder[T]
+
+	/*
+		Adds all elements of another list to the built list.
+
+		Parameters:
+		  - another - a list to add.
+
+		Returns:
+		  - updated builder.
+	*/
+	AddAll(another List[T]) ListBuilder[T]
+
+	/*
+		Creates the list.
+		The list is a snapshot, the builder can be used further and does not affect already built lists.
+
+		Returns:
+		  - built list.
+	*/
+	Build() List[T]
+}
+
+/*
+List builder, a reference type. Contains a list being built.
+
+Implements:
+  - ListBuilder.
+
+Type parameters:
+  - T - type of list elements.
+*/
+type sliceListBuilder[T comparable] struct {
+	list List[T]
+}
+
+/*
+List builder constructor.
+Creates a new list builder.
+
+Parameters:
+  - capacity - expected number of elements (0 if unknown).
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - pointer to the created builder.
+*/
+func NewListBuilder[T comparable](capacity int) ListBuilder[T] {
+	return &sliceListBuilder[T]{NewListWithCapacity[T](capacity)}
+}
+
+func (ego *sliceListBuilder[T]) Add(values ...T) ListBuilder[T] {
+	ego.list.Add(values...)
+	return ego
+}
+
+func (ego *sliceListBuilder[T]) AddIf(condition bool, values ...T) ListBuilder[T] {
+	if condition {
+		ego.list.Add(values...)
+	}
+	return ego
+}
+
+func (ego *sliceListBuilder[T]) AddAll(another List[T]) ListBuilder[T] {
+	ego.list.AddList(another)
+	return ego
+}
+
+func (ego *sliceListBuilder[T]) Build() List[T] {
+	return ego.list.Clone()
+}
+
+/*
+Dictionary builder, a tool for a fluent conditional construction of dictionaries.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+*/
+type DictBuilder[K comparable, V comparable] interface {
+
+	/*
+		Sets a field of the built dictionary.
+		If the key already exists, the value is overwritten.
+
+		Parameters:
+		  - key - key to set,
+		  - value - value to be set.
+
+		Returns:
+		  - updated builder.
+	*/
+	Set(key K, value V) DictBuilder[K, V]
+
+	/*
+		Sets a field of the built dictionary if a condition holds.
+
+		Parameters:
+		  - condition - whether the field should be set,
+		  - key - key to set,
+		  - value - value to be set.
+
+		Returns:
+		  - updated builder.
+	*/
+	SetIf(condition bool, key K, value V) DictBuilder[K, V]
+
+	/*
+		Sets all fields of a Go map to the built dictionary.
+		Existing keys are overwritten.
+
+		Parameters:
+		  - goMap - a map to set.
+
+		Returns:
+		  - updated builder.
+	*/
+	SetAll(goMap map[K]V) DictBuilder[K, V]
+
+	/*
+		Creates the dictionary.
+		The dictionary is a snapshot, the builder can be used further and does not affect already built dictionaries.
+
+		Returns:
+		  - built dictionary.
+	*/
+	Build() Dict[K, V]
+}
+
+/*
+Dictionary builder, a reference type. Contains a dictionary being built.
+
+Implements:
+  - DictBuilder.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+*/
+type mapDictBuilder[K comparable, V comparable] struct {
+	dict Dict[K, V]
+}
+
+/*
+Dictionary builder constructor.
+Creates a new dictionary builder.
+
+Parameters:
+  - capacity - expected number of fields (0 if unknown).
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+
+Returns:
+  - pointer to the created builder.
+*/
+func NewDictBuilder[K comparable, V comparable](capacity int) DictBuilder[K, V] {
+	return &mapDictBuilder[K, V]{NewDictWithCapacity[K, V](capacity)}
+}
+
+func (ego *mapDictBuilder[K, V]) Set(key K, value V) DictBuilder[K, V] {
+	ego.dict.Set(key, value)
+	return ego
+}
+
+func (ego *mapDictBuilder[K, V]) SetIf(condition bool, key K, value V) DictBuilder[K, V] {
+	if condition {
+		ego.dict.Set(key, value)
+	}
+	return ego
+}
+
+func (ego *mapDictBuilder[K, V]) SetAll(goMap map[K]V) DictBuilder[K, V] {
+	for key, value := range goMap {
+		ego.dict.Set(key, value)
+	}
+	return ego
+}
+
+func (ego *mapDictBuilder[K, V]) Build() Dict[K, V] {
+	return ego.dict.Clone()
+}
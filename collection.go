@@ -5,12 +5,326 @@ Additional tools
 package collection
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/url"
+	"reflect"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 )
 
+/*
+Common interface of lists, dictionaries, sets and other collections of the library.
+*/
+type Collection interface {
+
+	/*
+		Gives a number of elements in the collection.
+
+		Returns:
+		  - number of elements.
+	*/
+	Count() int
+
+	/*
+		Checks whether the collection is empty.
+
+		Returns:
+		  - true if the collection has no elements, false otherwise.
+	*/
+	Empty() bool
+
+	/*
+		Serializes the collection.
+
+		Returns:
+		  - string representing the serialized collection.
+	*/
+	String() string
+}
+
+/*
+Value whose String method produces a valid JSON if only compatible types are used.
+Such values are not quoted when serialized inside a collection.
+*/
+type serializable interface {
+	fmt.Stringer
+
+	/*
+		Writes the serialized value into a builder.
+		Nested collections are written into the same builder.
+
+		Parameters:
+		  - b - builder to write into.
+	*/
+	serialize(b *strings.Builder)
+}
+
+/*
+Options of a human-readable serialization of lists and dictionaries.
+The zero value produces the same compact output as String.
+*/
+type SerializeOptions struct {
+
+	// Indent is a string repeated for each level of nesting, every element is written on its own line if it is not empty.
+	Indent string
+
+	// Spaced adds a space after each comma and colon (always true for an indented output).
+	Spaced bool
+
+	// SortKeys writes fields of dictionaries in ascending order of keys instead of the iteration order.
+	SortKeys bool
+
+	// MaxElements limits a number of written elements of each collection, the rest is summarized (no limit if not positive).
+	MaxElements int
+}
+
+/*
+Collection which can be serialized with custom options.
+*/
+type optionsSerializable interface {
+
+	/*
+		Writes the serialized collection into a builder.
+		Nested collections are written with the same options.
+
+		Parameters:
+		  - b - builder to write into,
+		  - opts - serialization options,
+		  - depth - level of nesting of the collection.
+	*/
+	serializeOptions(b *strings.Builder, opts SerializeOptions, depth int)
+}
+
+/*
+Converts a value of any type to string with custom options and writes it into a builder.
+Values which are not lists or dictionaries are written as by writeString.
+
+Parameters:
+  - b - builder to write into,
+  - value - value to convert,
+  - opts - serialization options,
+  - depth - level of nesting of the value.
+*/
+func writeStringOptions(b *strings.Builder, value any, opts SerializeOptions, depth int) {
+	if val, ok := value.(optionsSerializable); ok {
+		val.serializeOptions(b, opts, depth)
+	} else {
+		writeString(b, value)
+	}
+}
+
+/*
+Writes elements of a collection with custom options into a builder, without the enclosing brackets.
+Elements over the limit are summarized by their count.
+
+Parameters:
+  - b - builder to write into,
+  - opts - serialization options,
+  - depth - level of nesting of the collection,
+  - count - number of elements,
+  - element - function writing the i-th element.
+*/
+func writeElements(b *strings.Builder, opts SerializeOptions, depth int, count int, element func(i int)) {
+	if count == 0 {
+		return
+	}
+	shown := count
+	if opts.MaxElements > 0 && count > opts.MaxElements {
+		shown = opts.MaxElements
+	}
+	for i := 0; i < shown; i++ {
+		if i > 0 {
+			opts.writeSeparator(b)
+		}
+		opts.writeNewline(b, depth+1)
+		element(i)
+	}
+	if shown < count {
+		opts.writeSeparator(b)
+		opts.writeNewline(b, depth+1)
+		fmt.Fprintf(b, "…and %d more", count-shown)
+	}
+	opts.writeNewline(b, depth)
+}
+
+/*
+Writes a separator of two elements.
+
+Parameters:
+  - b - builder to write into.
+*/
+func (ego SerializeOptions) writeSeparator(b *strings.Builder) {
+	b.WriteByte(',')
+	if ego.Spaced && ego.Indent == "" {
+		b.WriteByte(' ')
+	}
+}
+
+/*
+Writes a separator of a key and a value.
+
+Parameters:
+  - b - builder to write into.
+*/
+func (ego SerializeOptions) writeColon(b *strings.Builder) {
+	b.WriteByte(':')
+	if ego.Spaced || ego.Indent != "" {
+		b.WriteByte(' ')
+	}
+}
+
+/*
+Starts a new line indented to a given depth, if the output is indented.
+
+Parameters:
+  - b - builder to write into,
+  - depth - level of nesting.
+*/
+func (ego SerializeOptions) writeNewline(b *strings.Builder, depth int) {
+	if ego.Indent != "" {
+		b.WriteByte('\n')
+		for i := 0; i < depth; i++ {
+			b.WriteString(ego.Indent)
+		}
+	}
+}
+
+/*
+Finishes a summary of a collection by a number of omitted elements, a closing bracket and the total count.
+
+Parameters:
+  - b - builder to write into,
+  - close - closing bracket,
+  - shown - number of written elements,
+  - count - total number of elements.
+*/
+func writeSummaryEnd(b *strings.Builder, close byte, shown int, count int) {
+	if shown < count {
+		if shown > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(b, "… +%d more", count-shown)
+	}
+	b.WriteByte(close)
+	fmt.Fprintf(b, " (count=%d)", count)
+}
+
+/*
+Collection which can be formatted by the fmt package.
+*/
+type formattable interface {
+	fmt.Stringer
+
+	/*
+		Serializes the collection with custom options.
+
+		Parameters:
+		  - opts - serialization options.
+
+		Returns:
+		  - string representing the serialized collection.
+	*/
+	StringOpts(opts SerializeOptions) string
+
+	/*
+		Serializes only a given number of elements of the collection.
+
+		Parameters:
+		  - maxElements - maximal number of serialized elements.
+
+		Returns:
+		  - string summarizing the collection.
+	*/
+	Summary(maxElements int) string
+}
+
+/*
+Formats a collection for the fmt package.
+Verbs %v and %s produce the output of String, %+v an indented form and %q a quoted String.
+Precision limits the number of written elements (as Summary does), width pads the output with spaces.
+
+Parameters:
+  - collection - collection to format,
+  - f - formatter state,
+  - verb - formatting verb.
+*/
+func formatCollection(collection formattable, f fmt.State, verb rune) {
+	var result string
+	precision, limited := f.Precision()
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		result = collection.StringOpts(SerializeOptions{Indent: "  ", MaxElements: precision})
+	case verb == 'v' || verb == 's':
+		if limited {
+			result = collection.Summary(precision)
+		} else {
+			result = collection.String()
+		}
+	case verb == 'q':
+		result = strconv.Quote(collection.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, collection.String())
+		return
+	}
+	if width, ok := f.Width(); ok {
+		if padding := width - utf8.RuneCountInString(result); padding > 0 {
+			if f.Flag('-') {
+				result += strings.Repeat(" ", padding)
+			} else {
+				result = strings.Repeat(" ", padding) + result
+			}
+		}
+	}
+	io.WriteString(f, result)
+}
+
+/*
+Collection which can be copied including its nested collections.
+*/
+type deepCloneable interface {
+
+	/*
+		Creates a deep copy of the collection.
+
+		Returns:
+		  - copied collection.
+	*/
+	deepClone() any
+}
+
+/*
+Copies a value. Nested lists and dictionaries are copied recursively, other values are returned as they are.
+
+Parameters:
+  - value - value to copy.
+
+Type parameters:
+  - T - type of the value.
+
+Returns:
+  - copied value.
+*/
+func deepCopy[T any](value T) T {
+	if cloneable, ok := any(value).(deepCloneable); ok {
+		return cloneable.deepClone().(T)
+	}
+	return value
+}
+
 /*
 Converts a value of any type to string.
+Times are formatted according to RFC 3339, byte slices are encoded to base64
+and outputs of other Stringers are quoted, so the result stays a valid JSON.
 
 Parameters:
   - value - value to convert.
@@ -19,40 +333,126 @@ Returns:
   - value converted to string.
 */
 func toString(value any) string {
+	var b strings.Builder
+	writeString(&b, value)
+	return b.String()
+}
+
+/*
+Converts a value of any type to string and writes it into a builder.
+Nested collections are written into the same builder instead of being converted separately.
+
+Parameters:
+  - b - builder to write into,
+  - value - value to convert.
+*/
+func writeString(b *strings.Builder, value any) {
 	switch val := any(value).(type) {
 	case nil:
-		return "null"
+		b.WriteString("null")
 	case string:
-		return strconv.Quote(val)
+		b.WriteString(strconv.Quote(val))
 	case bool:
-		return strconv.FormatBool(val)
+		b.WriteString(strconv.FormatBool(val))
 	case int:
-		return strconv.Itoa(val)
+		b.WriteString(strconv.Itoa(val))
 	case int64:
-		return strconv.FormatInt(val, 10)
+		b.WriteString(strconv.FormatInt(val, 10))
 	case int32:
-		return strconv.FormatInt(int64(val), 10)
+		b.WriteString(strconv.FormatInt(int64(val), 10))
 	case int16:
-		return strconv.FormatInt(int64(val), 10)
+		b.WriteString(strconv.FormatInt(int64(val), 10))
 	case int8:
-		return strconv.FormatInt(int64(val), 10)
+		b.WriteString(strconv.FormatInt(int64(val), 10))
 	case uint64:
-		return strconv.FormatUint(val, 10)
+		b.WriteString(strconv.FormatUint(val, 10))
 	case uint32:
-		return strconv.FormatUint(uint64(val), 10)
+		b.WriteString(strconv.FormatUint(uint64(val), 10))
 	case uint16:
-		return strconv.FormatUint(uint64(val), 10)
+		b.WriteString(strconv.FormatUint(uint64(val), 10))
 	case uint8:
-		return strconv.FormatUint(uint64(val), 10)
+		b.WriteString(strconv.FormatUint(uint64(val), 10))
 	case float64:
-		return strconv.FormatFloat(val, 'f', -1, 64)
+		b.WriteString(strconv.FormatFloat(val, 'f', -1, 64))
 	case float32:
-		return strconv.FormatFloat(float64(val), 'f', -1, 32)
+		b.WriteString(strconv.FormatFloat(float64(val), 'f', -1, 32))
+	case time.Time:
+		b.WriteString(strconv.Quote(val.Format(time.RFC3339Nano)))
+	case time.Duration:
+		b.WriteString(strconv.Quote(val.String()))
+	case []byte:
+		b.WriteString(strconv.Quote(base64.StdEncoding.EncodeToString(val)))
+	case map[string]any:
+		writeMap(b, val)
+	case map[string]string:
+		writeMap(b, val)
+	case map[string]int:
+		writeMap(b, val)
+	case []any:
+		b.WriteByte('[')
+		for i, item := range val {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeString(b, item)
+		}
+		b.WriteByte(']')
+	case serializable:
+		val.serialize(b)
 	case fmt.Stringer:
-		return val.String()
+		b.WriteString(strconv.Quote(val.String()))
 	default:
-		return fmt.Sprintf("%+v", val)
+		fmt.Fprintf(b, "%+v", val)
+	}
+}
+
+/*
+Writes a Go map with string keys as a JSON object.
+Keys are sorted, so the output is deterministic.
+
+Parameters:
+  - b - builder to write into,
+  - goMap - map to write.
+
+Type parameters:
+  - V - type of map values.
+*/
+func writeMap[V any](b *strings.Builder, goMap map[string]V) {
+	keys := make([]string, 0, len(goMap))
+	for key := range goMap {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	b.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Quote(key))
+		b.WriteByte(':')
+		writeString(b, goMap[key])
 	}
+	b.WriteByte('}')
+}
+
+/*
+Sums numbers of elements of collections of any kinds.
+Nil collections are skipped.
+
+Parameters:
+  - collections... - any amount of collections.
+
+Returns:
+  - total number of elements.
+*/
+func TotalCount(collections ...Collection) int {
+	total := 0
+	for _, collection := range collections {
+		if collection != nil {
+			total += collection.Count()
+		}
+	}
+	return total
 }
 
 /*
@@ -74,13 +474,126 @@ Returns:
   - new dictionary.
 */
 func MapDict[K comparable, V comparable, N comparable](dict Dict[K, V], function func(K, V) N) Dict[K, N] {
-	new := NewDict[K, N]()
+	new := NewDictWithCapacity[K, N](dict.Count())
 	dict.ForEach(func(key K, value V) {
 		new.Set(key, function(key, value))
 	})
 	return new
 }
 
+/*
+Copies a dictionary and transforms each field, including its key, by a given mapping function.
+Both the keys and the values can be of different types than the original ones.
+If more fields are mapped to the same key, the last one wins (the order is not defined for unordered dictionaries).
+The old dictionary remains unchanged.
+
+Parameters:
+  - dict - old dictionary,
+  - function - anonymous function returning a new key and a new value of the field.
+
+Type parameters:
+  - K - type of old dictionary keys,
+  - V - type of old dictionary values,
+  - NK - type of new dictionary keys,
+  - NV - type of new dictionary values.
+
+Returns:
+  - new dictionary.
+*/
+func MapDictFull[K comparable, V comparable, NK comparable, NV comparable](dict Dict[K, V], function func(K, V) (NK, NV)) Dict[NK, NV] {
+	new := NewDictWithCapacity[NK, NV](dict.Count())
+	dict.ForEach(func(key K, value V) {
+		new.Set(function(key, value))
+	})
+	return new
+}
+
+/*
+Exports keys of a dictionary into a list.
+Same as the Keys method, usable as a function value in generic code.
+
+Parameters:
+  - dict - dictionary to export.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+
+Returns:
+  - list of keys.
+*/
+func DictKeysList[K comparable, V comparable](dict Dict[K, V]) List[K] {
+	return dict.Keys()
+}
+
+/*
+Exports values of a dictionary into a list.
+Same as the Values method, usable as a function value in generic code.
+
+Parameters:
+  - dict - dictionary to export.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+
+Returns:
+  - list of values.
+*/
+func DictValuesList[K comparable, V comparable](dict Dict[K, V]) List[V] {
+	return dict.Values()
+}
+
+/*
+Pairs values of two dictionaries with the same keys.
+Only keys present in both dictionaries appear in the result.
+
+Parameters:
+  - a - first dictionary,
+  - b - second dictionary.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - A - type of values of the first dictionary,
+  - B - type of values of the second dictionary.
+
+Returns:
+  - new dictionary of pairs of values.
+*/
+func ZipDicts[K comparable, A comparable, B comparable](a Dict[K, A], b Dict[K, B]) Dict[K, Pair[A, B]] {
+	return ZipDictsWith(a, b, func(_ K, first A, second B) Pair[A, B] {
+		return Pair[A, B]{first, second}
+	})
+}
+
+/*
+Combines values of two dictionaries with the same keys by a given function.
+Only keys present in both dictionaries appear in the result.
+
+Parameters:
+  - a - first dictionary,
+  - b - second dictionary,
+  - function - anonymous function combining a key and its values from both dictionaries.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - A - type of values of the first dictionary,
+  - B - type of values of the second dictionary,
+  - C - type of values of the new dictionary.
+
+Returns:
+  - new dictionary of combined values.
+*/
+func ZipDictsWith[K comparable, A comparable, B comparable, C comparable](a Dict[K, A], b Dict[K, B], function func(K, A, B) C) Dict[K, C] {
+	result := NewDictWithCapacity[K, C](min(a.Count(), b.Count()))
+	a.ForEach(func(key K, first A) {
+		if second, ok := b.getVal()[key]; ok {
+			result.Set(key, function(key, first, second))
+		}
+	})
+	return result
+}
+
 /*
 Copies a list and modifies each element by a given mapping function.
 The resulting element can be of a different type than the original one.
@@ -99,9 +612,857 @@ Returns:
   - new list.
 */
 func MapList[T comparable, N comparable](list List[T], function func(T) N) List[N] {
-	new := NewList[N]()
+	new := NewListWithCapacity[N](list.Count())
 	list.ForEach(func(value T) {
 		new.Add(function(value))
 	})
 	return new
 }
+
+/*
+Copies a list and modifies each element by a given mapping function which also gets the position of the element.
+The resulting element can be of a different type than the original one.
+The function has two parameters: index of the current element and its value.
+The old list remains unchanged.
+
+Parameters:
+  - list - old list,
+  - function - anonymous function to be executed.
+
+Type parameters:
+  - T - type of old list elements,
+  - N - type of new list elements.
+
+Returns:
+  - new list.
+*/
+func MapListIndexed[T comparable, N comparable](list List[T], function func(int, T) N) List[N] {
+	new := NewListWithCapacity[N](list.Count())
+	list.ForEachIndexed(func(i int, value T) {
+		new.Add(function(i, value))
+	})
+	return new
+}
+
+/*
+Copies a list, modifies each element by a given mapping function and keeps only the successfully mapped ones.
+The resulting element can be of a different type than the original one.
+The function has one parameter, the current element, and returns the new element
+and true if it should be kept.
+The old list remains unchanged.
+
+Parameters:
+  - list - old list,
+  - function - anonymous function to be executed.
+
+Type parameters:
+  - T - type of old list elements,
+  - N - type of new list elements.
+
+Returns:
+  - new list.
+*/
+func FilterMapList[T comparable, N comparable](list List[T], function func(T) (N, bool)) List[N] {
+	new := NewList[N]()
+	list.ForEach(func(value T) {
+		if item, ok := function(value); ok {
+			new.Add(item)
+		}
+	})
+	return new
+}
+
+/*
+Copies a list and modifies each element by a given fallible mapping function.
+The resulting element can be of a different type than the original one.
+The function has one parameter, the current element.
+Elements for which the function fails are left out and all the errors are joined,
+so the caller can either use the partial result or fail as a whole.
+The old list remains unchanged.
+
+Parameters:
+  - list - old list,
+  - function - anonymous function to be executed.
+
+Type parameters:
+  - T - type of old list elements,
+  - N - type of new list elements.
+
+Returns:
+  - new list of successfully mapped elements,
+  - all errors returned by the function joined by errors.Join (nil if there are none).
+*/
+func MapListErr[T comparable, N comparable](list List[T], function func(T) (N, error)) (List[N], error) {
+	new := NewListWithCapacity[N](list.Count())
+	var errs []error
+	list.ForEach(func(value T) {
+		if item, err := function(value); err != nil {
+			errs = append(errs, err)
+		} else {
+			new.Add(item)
+		}
+	})
+	return new, errors.Join(errs...)
+}
+
+/*
+Converts a list to a dictionary.
+Each element is converted to a key-value pair by a given function.
+If more elements produce the same key, the value of the last one is used.
+The old list remains unchanged.
+
+Parameters:
+  - list - list to convert,
+  - function - anonymous function to be executed.
+
+Type parameters:
+  - T - type of list elements,
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+
+Returns:
+  - new dictionary.
+*/
+func ListToDict[T comparable, K comparable, V comparable](list List[T], function func(T) (K, V)) Dict[K, V] {
+	result := NewDictWithCapacity[K, V](list.Count())
+	list.ForEach(func(value T) {
+		result.Set(function(value))
+	})
+	return result
+}
+
+/*
+Converts a list into a deterministic dictionary mapping indexes to the elements.
+The dictionary is a snapshot, later changes of the list are not reflected.
+
+Parameters:
+  - list - list to convert.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - new dictionary of elements indexed by their positions.
+*/
+func ListToDictIndex[T comparable](list List[T]) Dict[int, T] {
+	list.assert()
+	result := NewDictDeterministic[int, T]()
+	for i, item := range list.getVal() {
+		result.Set(i, item)
+	}
+	return result
+}
+
+/*
+Pairs elements of a list with their positions, so the original indexes can be carried through later filtering or sorting.
+The new list is a snapshot, later changes of the old list are not reflected.
+
+Parameters:
+  - list - list to enumerate.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - new list of pairs (index, element).
+*/
+func Enumerate[T comparable](list List[T]) List[Pair[int, T]] {
+	list.assert()
+	result := make([]Pair[int, T], list.Count())
+	for i, item := range list.getVal() {
+		result[i] = Pair[int, T]{i, item}
+	}
+	return &sliceList[Pair[int, T]]{val: result}
+}
+
+/*
+Merges lists by taking one element from each of them in turn.
+Shorter lists drop out when they are exhausted, nil lists are treated as empty.
+The old lists remain unchanged.
+
+Parameters:
+  - lists... - any amount of lists to merge.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - new list.
+*/
+func InterleaveLists[T comparable](lists ...List[T]) List[T] {
+	count, longest := 0, 0
+	for _, list := range lists {
+		if list != nil {
+			count += list.Count()
+			if list.Count() > longest {
+				longest = list.Count()
+			}
+		}
+	}
+	result := make([]T, 0, count)
+	for i := 0; i < longest; i++ {
+		for _, list := range lists {
+			if list != nil && i < list.Count() {
+				result = append(result, list.getVal()[i])
+			}
+		}
+	}
+	return &sliceList[T]{val: result}
+}
+
+/*
+Creates a new list containing the elements of a list repeated a given number of times.
+The new list is allocated once at its final size, the old list remains unchanged.
+Panics if the number of repetitions is negative.
+
+Parameters:
+  - list - list to repeat,
+  - count - number of repetitions.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - new list.
+*/
+func RepeatList[T comparable](list List[T], count int) List[T] {
+	list.assert()
+	if count < 0 {
+		panic(invalidArgument("number of repetitions %d cannot be negative", count))
+	}
+	result := make([]T, 0, count*list.Count())
+	for i := 0; i < count; i++ {
+		result = append(result, list.getVal()...)
+	}
+	return &sliceList[T]{val: result}
+}
+
+/*
+Splits a list into windows of consecutive elements.
+The first window starts at index 0, each next one starts step elements later.
+Only complete windows are created, trailing elements which do not fill a whole window are left out.
+Windows are new lists, the old list remains unchanged.
+Panics if the size or the step is not positive.
+
+Parameters:
+  - list - list to split,
+  - size - number of elements in each window,
+  - step - distance between starts of two neighbouring windows.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - list of windows.
+*/
+func Window[T comparable](list List[T], size int, step int) List[List[T]] {
+	list.assert()
+	if size <= 0 || step <= 0 {
+		panic(invalidArgument("window size %d and step %d have to be positive", size, step))
+	}
+	count := 0
+	if list.Count() >= size {
+		count = (list.Count()-size)/step + 1
+	}
+	result := make([]List[T], count)
+	for i := range result {
+		start := i * step
+		window := &sliceList[T]{val: make([]T, size)}
+		copy(window.getVal(), list.getVal()[start:start+size])
+		result[i] = window
+	}
+	return &sliceList[List[T]]{val: result}
+}
+
+/*
+Splits a list into chunks of consecutive elements.
+A new chunk is started whenever a given predicate does not hold for the previous and the current element,
+e.g. equality groups runs of equal elements and a gap check splits a sorted list into sessions.
+The chunks preserve the order of the elements and jointly cover the whole list.
+Chunks are new lists, the old list remains unchanged.
+
+Parameters:
+  - list - list to split,
+  - function - predicate with two parameters, the previous and the current element, returning true if they belong to the same chunk.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - list of chunks.
+*/
+func ChunkBy[T comparable](list List[T], function func(T, T) bool) List[List[T]] {
+	list.assert()
+	result := make([]List[T], 0)
+	start := 0
+	for i := 1; i <= list.Count(); i++ {
+		if i == list.Count() || !function(list.getVal()[i-1], list.getVal()[i]) {
+			chunk := &sliceList[T]{val: make([]T, i-start)}
+			copy(chunk.getVal(), list.getVal()[start:i])
+			result = append(result, chunk)
+			start = i
+		}
+	}
+	return &sliceList[List[T]]{val: result}
+}
+
+/*
+Compresses runs of equal consecutive elements of a list.
+Each run is represented by a pair of the element and the length of the run.
+The old list remains unchanged.
+
+Parameters:
+  - list - list to compress.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - list of pairs (element, length of the run).
+*/
+func RunLengthEncode[T comparable](list List[T]) List[Pair[T, int]] {
+	list.assert()
+	result := make([]Pair[T, int], 0)
+	for _, item := range list.getVal() {
+		if last := len(result) - 1; last >= 0 && result[last].first == item {
+			result[last].second++
+		} else {
+			result = append(result, Pair[T, int]{item, 1})
+		}
+	}
+	return &sliceList[Pair[T, int]]{val: result}
+}
+
+/*
+Expands runs produced by RunLengthEncode back to a list.
+Panics if some length of a run is not positive.
+
+Parameters:
+  - runs - list of pairs (element, length of the run).
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - expanded list.
+*/
+func RunLengthDecode[T comparable](runs List[Pair[T, int]]) List[T] {
+	runs.assert()
+	count := 0
+	for i, run := range runs.getVal() {
+		if run.second <= 0 {
+			panic(invalidArgument("length %d of run %d has to be positive", run.second, i))
+		}
+		count += run.second
+	}
+	result := make([]T, 0, count)
+	for _, run := range runs.getVal() {
+		for j := 0; j < run.second; j++ {
+			result = append(result, run.first)
+		}
+	}
+	return &sliceList[T]{val: result}
+}
+
+/*
+Chooses a random element of a list with a probability proportional to its weight.
+Panics if the lists differ in length, some weight is negative or NaN, or all weights are zero.
+
+Parameters:
+  - items - list to choose from,
+  - weights - non-negative weights of the elements at the same positions,
+  - r - source of randomness (the global one if nil).
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - chosen element.
+*/
+func WeightedSample[T comparable](items List[T], weights List[float64], r *rand.Rand) T {
+	cumulative := cumulativeWeights(items, weights)
+	return items.getVal()[weightedIndex(cumulative, r)]
+}
+
+/*
+Chooses n random elements of a list with probabilities proportional to their weights.
+Without replacement, each element can be chosen at most once and its weight is excluded from the following draws.
+Panics if the lists differ in length, some weight is negative or NaN, all weights are zero,
+or there are less than n elements with a positive weight to choose from without replacement.
+
+Parameters:
+  - items - list to choose from,
+  - weights - non-negative weights of the elements at the same positions,
+  - n - number of draws,
+  - replace - true if an element can be chosen repeatedly,
+  - r - source of randomness (the global one if nil).
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - list of chosen elements in the order of the draws.
+*/
+func WeightedSampleN[T comparable](items List[T], weights List[float64], n int, replace bool, r *rand.Rand) List[T] {
+	if n < 0 {
+		panic(invalidArgument("number of draws %d cannot be negative", n))
+	}
+	cumulative := cumulativeWeights(items, weights)
+	result := make([]T, n)
+	if replace {
+		for i := range result {
+			result[i] = items.getVal()[weightedIndex(cumulative, r)]
+		}
+		return &sliceList[T]{val: result}
+	}
+	remaining := append(make([]float64, 0, weights.Count()), weights.getVal()...)
+	for i := range result {
+		if cumulative[len(cumulative)-1] <= 0 {
+			panic(invalidArgument("only %d elements with a positive weight to draw %d times without replacement", i, n))
+		}
+		index := weightedIndex(cumulative, r)
+		result[i] = items.getVal()[index]
+		remaining[index] = 0
+		total := 0.0
+		for j, weight := range remaining {
+			total += weight
+			cumulative[j] = total
+		}
+	}
+	return &sliceList[T]{val: result}
+}
+
+/*
+Validates weights of list elements and computes their cumulative sums.
+
+Parameters:
+  - items - list of elements,
+  - weights - weights of the elements.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - cumulative sums of the weights.
+*/
+func cumulativeWeights[T comparable](items List[T], weights List[float64]) []float64 {
+	items.assert()
+	weights.assert()
+	if items.Count() != weights.Count() {
+		panic(invalidArgument("%d weights do not match %d elements", weights.Count(), items.Count()))
+	}
+	cumulative := make([]float64, weights.Count())
+	total := 0.0
+	for i, weight := range weights.getVal() {
+		if math.IsNaN(weight) {
+			panic(invalidArgument("weight at index %d is not a number", i))
+		}
+		if weight < 0 {
+			panic(invalidArgument("weight %v at index %d is negative", weight, i))
+		}
+		total += weight
+		cumulative[i] = total
+	}
+	if total <= 0 {
+		panic(invalidArgument("all weights are zero"))
+	}
+	return cumulative
+}
+
+/*
+Chooses a random index with a uniform probability.
+
+Parameters:
+  - count - number of indices, has to be positive,
+  - r - source of randomness (the global one if nil).
+
+Returns:
+  - chosen index.
+*/
+func randomIndex(count int, r *rand.Rand) int {
+	if r == nil {
+		return rand.Intn(count)
+	}
+	return r.Intn(count)
+}
+
+/*
+Chooses a random index with a probability proportional to its weight.
+
+Parameters:
+  - cumulative - cumulative sums of the weights, the last one has to be positive,
+  - r - source of randomness (the global one if nil).
+
+Returns:
+  - chosen index.
+*/
+func weightedIndex(cumulative []float64, r *rand.Rand) int {
+	var random float64
+	if r == nil {
+		random = rand.Float64()
+	} else {
+		random = r.Float64()
+	}
+	target := random * cumulative[len(cumulative)-1]
+	// the first index whose cumulative weight exceeds the target, elements of zero weight are never chosen
+	index, _ := slices.BinarySearchFunc(cumulative, target, func(sum, target float64) int {
+		if sum <= target {
+			return -1
+		}
+		return 1
+	})
+	// rounding of the target can reach the total, the last element of a positive weight is chosen then
+	for index == len(cumulative) || (index > 0 && cumulative[index] == cumulative[index-1]) {
+		index--
+	}
+	return index
+}
+
+/*
+Converts a list of elements of any type to a list of elements of a specific type.
+Each element has to be assertable to the target type.
+
+Parameters:
+  - list - list to convert.
+
+Type parameters:
+  - N - type of new list elements.
+
+Returns:
+  - new list,
+  - error naming the first element which cannot be converted (nil if all elements can be converted).
+*/
+func CastList[N comparable](list List[any]) (List[N], error) {
+	result := NewList[N]()
+	for i, value := range list.GoSlice() {
+		item, ok := value.(N)
+		if !ok {
+			return nil, fmt.Errorf("element %d of type %T cannot be cast to %s", i, value, reflect.TypeOf((*N)(nil)).Elem())
+		}
+		result.Add(item)
+	}
+	return result, nil
+}
+
+/*
+Converts a list of elements of any type to a list of elements of a specific type.
+Panics with the index and the actual type of the first element which cannot be converted.
+
+Parameters:
+  - list - list to convert.
+
+Type parameters:
+  - N - type of new list elements.
+
+Returns:
+  - new list.
+*/
+func MustCastList[N comparable](list List[any]) List[N] {
+	result, err := CastList[N](list)
+	if err != nil {
+		panic(err.Error())
+	}
+	return result
+}
+
+/*
+Creates a new list containing only the elements of a specific type.
+Other elements (including nils) are left out.
+The old list remains unchanged.
+
+Parameters:
+  - list - list to filter.
+
+Type parameters:
+  - N - type of new list elements.
+
+Returns:
+  - filtered list.
+*/
+func FilterType[N comparable](list List[any]) List[N] {
+	result, _ := PartitionType[N](list)
+	return result
+}
+
+/*
+Splits a list into elements of a specific type and the others.
+The old list remains unchanged.
+
+Parameters:
+  - list - list to split.
+
+Type parameters:
+  - N - type of elements of the first list.
+
+Returns:
+  - list of elements of the given type,
+  - list of the remaining elements.
+*/
+func PartitionType[N comparable](list List[any]) (List[N], List[any]) {
+	matching := NewList[N]()
+	rest := NewList[any]()
+	list.ForEach(func(value any) {
+		if item, ok := value.(N); ok {
+			matching.Add(item)
+		} else {
+			rest.Add(value)
+		}
+	})
+	return matching, rest
+}
+
+/*
+Acquires a value nested in a tree of dictionaries, e.g. a parsed JSON.
+Each key of the path selects a field of the dictionary found by the previous key.
+Does not panic if some key does not exist or the value has a different type.
+
+Parameters:
+  - root - dictionary to start at,
+  - keys... - path of keys leading to the value.
+
+Type parameters:
+  - V - type of the value.
+
+Returns:
+  - found value,
+  - true if the path exists and the value is of the given type, false otherwise.
+*/
+func GetPath[V comparable](root Dict[string, any], keys ...string) (V, bool) {
+	var zero V
+	var node any = root
+	for _, key := range keys {
+		dict, ok := node.(Dict[string, any])
+		if !ok || dict == nil || dict.getVal() == nil {
+			return zero, false
+		}
+		if node, ok = dict.getVal()[key]; !ok {
+			return zero, false
+		}
+	}
+	value, ok := node.(V)
+	return value, ok
+}
+
+/*
+Sets a value nested in a tree of dictionaries, e.g. a parsed JSON.
+Each key of the path selects a field of the dictionary found by the previous key,
+missing intermediate dictionaries are created, the last key is set to the value.
+
+Parameters:
+  - root - dictionary to start at,
+  - value - value to be set,
+  - keys... - path of keys leading to the value.
+
+Type parameters:
+  - V - type of the value.
+
+Returns:
+  - error if the path is empty or some intermediate value is not a dictionary, nil otherwise.
+*/
+func SetPath[V comparable](root Dict[string, any], value V, keys ...string) error {
+	if len(keys) == 0 {
+		return errors.New("path is empty")
+	}
+	root.assert()
+	node := root
+	for i, key := range keys[:len(keys)-1] {
+		child, ok := node.getVal()[key]
+		if !ok {
+			child = NewDict[string, any]()
+			node.Set(key, child)
+		}
+		dict, ok := child.(Dict[string, any])
+		if !ok || dict == nil || dict.getVal() == nil {
+			return fmt.Errorf("value of type %T under key %s at depth %d is not a dictionary", child, toString(key), i)
+		}
+		node = dict
+	}
+	node.Set(keys[len(keys)-1], value)
+	return nil
+}
+
+/*
+Converts a dictionary to URL query parameters.
+
+Parameters:
+  - dict - dictionary of parameters.
+
+Returns:
+  - created url.Values with one value for each key.
+*/
+func DictToURLValues(dict Dict[string, string]) url.Values {
+	dict.assert()
+	values := make(url.Values, dict.Count())
+	for key, value := range dict.getVal() {
+		values.Set(key, value)
+	}
+	return values
+}
+
+/*
+Encodes a dictionary as a URL query string.
+Keys are sorted, so the result is deterministic. Keys and values are escaped by net/url.
+
+Parameters:
+  - dict - dictionary of parameters.
+
+Returns:
+  - encoded query string without the leading question mark.
+*/
+func DictToQueryString(dict Dict[string, string]) string {
+	return DictToURLValues(dict).Encode()
+}
+
+/*
+Converts URL query parameters to a dictionary.
+Each key is mapped to a list of all its values in the original order.
+
+Parameters:
+  - values - URL query parameters, e.g. from url.ParseQuery.
+
+Returns:
+  - created dictionary.
+*/
+func NewDictFromURLValues(values url.Values) Dict[string, List[string]] {
+	dict := NewDictWithCapacity[string, List[string]](len(values))
+	for key, list := range values {
+		dict.Set(key, NewListFrom(slices.Clone(list)))
+	}
+	return dict
+}
+
+/*
+Parses a JSON document into collections.
+Objects become dictionaries of type Dict[string, any], arrays lists of type List[any],
+integral numbers become int and other numbers float64.
+
+Parameters:
+  - data - JSON document.
+
+Returns:
+  - parsed value,
+  - error if the document is not a valid JSON, nil otherwise.
+*/
+func parseJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after the JSON value")
+	}
+	return fromNative(value), nil
+}
+
+/*
+Converts a value decoded by encoding/json or a YAML library into collections.
+Maps become dictionaries of type Dict[string, any] and slices lists of type List[any].
+
+Parameters:
+  - value - decoded value.
+
+Returns:
+  - converted value.
+*/
+func fromNative(value any) any {
+	switch val := value.(type) {
+	case map[string]any:
+		dict := NewDictWithCapacity[string, any](len(val))
+		for key, field := range val {
+			dict.getVal()[key] = fromNative(field)
+		}
+		return dict
+	case map[any]any:
+		dict := NewDictWithCapacity[string, any](len(val))
+		for key, field := range val {
+			dict.getVal()[fmt.Sprint(key)] = fromNative(field)
+		}
+		return dict
+	case []any:
+		list := &sliceList[any]{val: make([]any, len(val))}
+		for i, elem := range val {
+			list.getVal()[i] = fromNative(elem)
+		}
+		return list
+	case json.Number:
+		if integer, err := strconv.Atoi(val.String()); err == nil {
+			return integer
+		}
+		float, _ := val.Float64()
+		return float
+	default:
+		return value
+	}
+}
+
+/*
+Converts collections nested in a value into Go maps and slices.
+
+Parameters:
+  - value - value to convert.
+
+Returns:
+  - converted value.
+*/
+func toNative(value any) any {
+	if collection, ok := value.(interface{ goValue() any }); ok {
+		return collection.goValue()
+	}
+	return value
+}
+
+/*
+Converts a value produced by fromNative into a given type.
+Values of other types are converted through encoding/json, e.g. int to float64.
+
+Parameters:
+  - value - value to convert.
+
+Type parameters:
+  - T - target type.
+
+Returns:
+  - converted value,
+  - error if the value cannot be converted, nil otherwise.
+*/
+func convertValue[T any](value any) (T, error) {
+	if result, ok := value.(T); ok {
+		return result, nil
+	}
+	var result T
+	encoded, err := json.Marshal(toNative(value))
+	if err == nil {
+		err = json.Unmarshal(encoded, &result)
+	}
+	if err != nil {
+		return result, fmt.Errorf("cannot convert value of type %T to %T: %w", value, result, err)
+	}
+	return result, nil
+}
+
+/*
+Converts a key of a decoded object into a given type.
+Keys of other types than string are parsed as JSON, e.g. numeric keys.
+
+Parameters:
+  - key - key to convert.
+
+Type parameters:
+  - K - target type.
+
+Returns:
+  - converted key,
+  - error if the key cannot be converted, nil otherwise.
+*/
+func convertKey[K any](key string) (K, error) {
+	if result, ok := any(key).(K); ok {
+		return result, nil
+	}
+	var result K
+	if err := json.Unmarshal([]byte(key), &result); err != nil {
+		return result, fmt.Errorf("cannot convert key %q to %T: %w", key, result, err)
+	}
+	return result, nil
+}
@@ -1,12 +1,31 @@
 package collection_test
 
 import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	. "github.com/DanielSvub/collection"
 )
 
+type stringer struct {
+	text string
+}
+
+func (ego stringer) String() string {
+	return ego.text
+}
+
 func TestDict(t *testing.T) {
 
 	t.Run("basics", func(t *testing.T) {
@@ -24,6 +43,18 @@ func TestDict(t *testing.T) {
 		if !d.Values().Contains(2) {
 			t.Error("Value list should contain the value.")
 		}
+		if keys := d.KeysSlice(); len(keys) != 3 || !NewListFrom(keys).SortClone().Equals(d.Keys().SortClone()) {
+			t.Error("KeysSlice does not work properly.")
+		}
+		if values := d.ValuesSlice(); len(values) != 3 || !NewListFrom(values).SortClone().Equals(NewList(1, 2, 3)) {
+			t.Error("ValuesSlice does not work properly.")
+		}
+		if !d.SortedKeys().Equals(NewList("first", "second", "third")) {
+			t.Error("SortedKeys should return keys in ascending order.")
+		}
+		if !NewDictFrom(map[int]float64{1: 2.5, 2: -1, 3: 0}).SortedValues().Equals(NewList(-1.0, 0, 2.5)) {
+			t.Error("SortedValues should return values in ascending order.")
+		}
 		if !d.Contains(3) {
 			t.Error("Dict should contain value 3.")
 		}
@@ -42,10 +73,77 @@ func TestDict(t *testing.T) {
 		if d.Pluck("first", "second").Count() != 2 {
 			t.Error("Plucked Dict should have 2 fields.")
 		}
+		if except := d.CloneExcept("third", "missing"); !except.Equals(NewDict[string, int]().Set("first", 1).Set("second", 2)) || !d.KeyExists("third") {
+			t.Error("CloneExcept does not work properly.")
+		}
 		d.Unset("third")
 		if !NewDict[string, int]().Set("first", 1).Merge(NewDict[string, int]().Set("second", 2)).Equals(d) {
 			t.Error("Merge does not work properly.")
 		}
+		base := NewDict[string, int]().Set("a", 1)
+		if merged := base.Merge(); !merged.Equals(base) || merged.Set("z", 0) == base || base.KeyExists("z") {
+			t.Error("Merge without arguments should return a copy.")
+		}
+		first := NewDict[string, int]().Set("a", 2).Set("b", 2)
+		second := NewDict[string, int]().Set("b", 3).Set("c", 3)
+		third := NewDict[string, int]().Set("c", 4).Set("d", 4)
+		if !base.Merge(first).Equals(NewDictFrom(map[string]int{"a": 2, "b": 2})) ||
+			!base.Merge(first, second).Equals(NewDictFrom(map[string]int{"a": 2, "b": 3, "c": 3})) ||
+			!base.Merge(first, second, third).Equals(NewDictFrom(map[string]int{"a": 2, "b": 3, "c": 4, "d": 4})) ||
+			!base.Merge(nil, second).Equals(NewDictFrom(map[string]int{"a": 1, "b": 3, "c": 3})) {
+			t.Error("Merge of multiple dicts does not work properly.")
+		}
+		if base.Count() != 1 || first.Count() != 2 || second.Count() != 2 {
+			t.Error("Merge should not modify the merged dicts.")
+		}
+		bulk := NewDict[string, int]().Set("a", 1)
+		if bulk.SetAll(map[string]int{"a": 2, "b": 2}) != bulk || !bulk.Equals(NewDictFrom(map[string]int{"a": 2, "b": 2})) {
+			t.Error("SetAll should overwrite existing keys and return the receiver.")
+		}
+		if !bulk.SetPairs(NewPair("b", 3), NewPair("c", 3), NewPair("c", 4)).Equals(NewDictFrom(map[string]int{"a": 2, "b": 3, "c": 4})) {
+			t.Error("SetPairs should overwrite existing keys, the last pair wins.")
+		}
+		source := map[string]int{"x": 1}
+		copied := NewDict[string, int]().SetAll(source)
+		source["y"] = 2
+		if copied.KeyExists("y") || !NewDict[string, int]().SetAll(nil).SetPairs().Empty() {
+			t.Error("SetAll should copy the map.")
+		}
+		shared := map[string]int{}
+		NewDictFrom(shared).SetAll(map[string]int{"a": 1}).SetPairs(NewPair("b", 2))
+		if len(shared) != 2 || shared["a"] != 1 || shared["b"] != 2 {
+			t.Error("SetAll and SetPairs should write to the map of an empty dict created from it.")
+		}
+		exported := NewDict[string, int]()
+		goMap := exported.GoMap()
+		exported.SetAll(map[string]int{"a": 1})
+		if goMap["a"] != 1 {
+			t.Error("SetAll should write to the exported map of an empty dict.")
+		}
+		ordered := NewDictDeterministic[string, int]().SetPairs(NewPair("b", 1), NewPair("a", 2)).SetPairs(NewPair("c", 3), NewPair("b", 4))
+		if ordered.String() != `{"b":4,"a":2,"c":3}` {
+			t.Error("SetPairs should keep the insertion order of a deterministic dict.")
+		}
+		filled := first.Merge(second)
+		if filled.Fill(0) != filled || !filled.Equals(NewDictFrom(map[string]int{"a": 0, "b": 0, "c": 0})) {
+			t.Error("Fill should overwrite all values and keep the keys.")
+		}
+		if !NewDict[string, int]().Fill(1).Empty() {
+			t.Error("Fill of an empty dict should do nothing.")
+		}
+		if !first.SymmetricDifference(first).Empty() || !first.SymmetricDifference(first.Merge().Set("a", 9)).Empty() {
+			t.Error("SymmetricDifference of dicts with the same keys should be empty.")
+		}
+		if !base.SymmetricDifference(third).Equals(base.Merge(third)) {
+			t.Error("SymmetricDifference of disjoint dicts should be their union.")
+		}
+		if !first.SymmetricDifference(second).Equals(NewDictFrom(map[string]int{"a": 2, "c": 3})) ||
+			!second.SymmetricDifference(first).Equals(first.SymmetricDifference(second)) {
+			t.Error("SymmetricDifference should leave out shared keys.")
+		}
+		if !base.SymmetricDifference(nil).Equals(base) || !base.SymmetricDifference(NewDict[string, int]()).Equals(base) {
+			t.Error("SymmetricDifference with an empty dict should be a copy.")
+		}
 		json := d.String()
 		if json != `{"first":1,"second":2}` && json != `{"second":2,"first":1}` {
 			t.Error("Serialization does not work properly.")
@@ -56,16 +154,180 @@ func TestDict(t *testing.T) {
 		}
 	})
 
+	t.Run("stringOpts", func(t *testing.T) {
+		d := NewDictDeterministic[string, any]().
+			Set("name", "x").
+			Set("tags", NewList("a", "b", "c")).
+			Set("meta", NewDictDeterministic[string, any]().Set("z", 1).Set("a", NewList[int]()))
+		if d.StringOpts(SerializeOptions{}) != d.String() {
+			t.Error("Zero options should produce the same output as String.")
+		}
+		if result := d.StringPretty(); result != `{"name": "x", "tags": ["a", "b", "c"], "meta": {"z": 1, "a": []}}` {
+			t.Errorf("StringPretty does not work properly, got %s.", result)
+		}
+		if result := d.StringOpts(SerializeOptions{SortKeys: true}); result != `{"meta":{"a":[],"z":1},"name":"x","tags":["a","b","c"]}` {
+			t.Errorf("Sorting keys does not work properly, got %s.", result)
+		}
+		indented := "{\n  \"meta\": {\n    \"a\": [],\n    \"z\": 1\n  },\n  \"name\": \"x\",\n  \"tags\": [\n    \"a\",\n    \"b\",\n    \"c\"\n  ]\n}"
+		if result := d.StringOpts(SerializeOptions{Indent: "  ", SortKeys: true}); result != indented {
+			t.Errorf("Indentation does not work properly, got %s.", result)
+		}
+		if !json.Valid([]byte(indented)) {
+			t.Error("Indented output should be a valid JSON.")
+		}
+		truncated := "{\n\t\"name\": \"x\",\n\t\"tags\": [\n\t\t\"a\",\n\t\t\"b\",\n\t\t…and 1 more\n\t],\n\t…and 1 more\n}"
+		if result := d.StringOpts(SerializeOptions{Indent: "\t", MaxElements: 2}); result != truncated {
+			t.Errorf("Truncation with indentation does not work properly, got %s.", result)
+		}
+		if result := d.StringOpts(SerializeOptions{Spaced: true, SortKeys: true, MaxElements: 1}); result != `{"meta": {"a": [], …and 1 more}, …and 2 more}` {
+			t.Errorf("Truncation does not work properly, got %s.", result)
+		}
+		if result := d.StringOpts(SerializeOptions{MaxElements: 3}); result != d.String() {
+			t.Errorf("Collections within the limit should not be truncated, got %s.", result)
+		}
+		numbers := NewDict[int, string]().Set(10, "b").Set(9, "a")
+		if result := numbers.StringOpts(SerializeOptions{SortKeys: true}); result != `{9:"a",10:"b"}` {
+			t.Errorf("Numeric keys should be sorted naturally, got %s.", result)
+		}
+		l := NewList(1, 2, 3)
+		if l.Summary(5) != `[1,2,3] (count=3)` || l.Summary(3) != `[1,2,3] (count=3)` || l.Summary(0) != `[1,2,3] (count=3)` {
+			t.Error("Summary within the limit should contain all elements.")
+		}
+		if l.Summary(2) != `[1,2,… +1 more] (count=3)` || NewList[int]().Summary(2) != `[] (count=0)` {
+			t.Error("Summary over the limit does not work properly.")
+		}
+		big := NewListOf(1.5, 1000000)
+		if big.Summary(3) != `[1.5,1.5,1.5,… +999997 more] (count=1000000)` {
+			t.Error("Summary of a huge list does not work properly.")
+		}
+		ordered := NewDictDeterministic[string, int]().Set("a", 1).Set("b", 2).Set("c", 3)
+		if ordered.Summary(3) != `{"a":1,"b":2,"c":3} (count=3)` || ordered.Summary(1) != `{"a":1,… +2 more} (count=3)` {
+			t.Error("Summary of a dict does not work properly.")
+		}
+		if summary := NewDict[string, int]().Set("x", 1).Set("y", 1).Summary(1); summary != `{"x":1,… +1 more} (count=2)` && summary != `{"y":1,… +1 more} (count=2)` {
+			t.Errorf("Summary of an unordered dict does not work properly, got %s.", summary)
+		}
+		if NewList(1, 2).StringPretty() != `[1, 2]` || NewList[int]().StringOpts(SerializeOptions{Indent: " "}) != `[]` {
+			t.Error("List options do not work properly.")
+		}
+	})
+
+	t.Run("format", func(t *testing.T) {
+		d := NewDictDeterministic[string, any]().Set("a", 1).Set("b", NewList(1, 2, 3))
+		if result := fmt.Sprintf("%v", d); result != d.String() {
+			t.Errorf("Verb %%v should produce the same output as String, got %s.", result)
+		}
+		if result := fmt.Sprintf("%s", d); result != d.String() {
+			t.Errorf("Verb %%s should produce the same output as String, got %s.", result)
+		}
+		if result := fmt.Sprintf("%+v", d); result != "{\n  \"a\": 1,\n  \"b\": [\n    1,\n    2,\n    3\n  ]\n}" {
+			t.Errorf("Verb %%+v should produce an indented output, got %s.", result)
+		}
+		if result := fmt.Sprintf("%q", NewList("x")); result != `"[\"x\"]"` {
+			t.Errorf("Verb %%q should produce a quoted output, got %s.", result)
+		}
+		if result := fmt.Sprintf("%.1v", d); result != `{"a":1,… +1 more} (count=2)` {
+			t.Errorf("Precision should truncate the output, got %s.", result)
+		}
+		if result := fmt.Sprintf("%.2s", NewList(1, 2, 3)); result != `[1,2,… +1 more] (count=3)` {
+			t.Errorf("Precision should truncate the output, got %s.", result)
+		}
+		if result := fmt.Sprintf("%+.1v", NewList(1, 2)); result != "[\n  1,\n  …and 1 more\n]" {
+			t.Errorf("Precision should truncate the indented output, got %s.", result)
+		}
+		if result := fmt.Sprintf("%7v|%-7v|", NewList(1), NewList(2)); result != "    [1]|[2]    |" {
+			t.Errorf("Width should pad the output, got %s.", result)
+		}
+		if result := fmt.Sprintf("%d", NewList(1)); result != "%!d([1])" {
+			t.Errorf("Unsupported verbs should be reported, got %s.", result)
+		}
+	})
+
 	t.Run("equality", func(t *testing.T) {
 		if NewDict[string, int]().Set("first", 1).Equals(NewDict[string, int]().Set("second", 2)) {
 			t.Error("Equality check does not work properly.")
 		}
+		if NewDict[string, int]().Set("a", 0).Equals(NewDict[string, int]().Set("b", 0)) {
+			t.Error("Dicts with zero values under different keys should not be equal.")
+		}
+		if NewDict[string, int]().Set("a", 0).Set("b", 1).Equals(NewDict[string, int]().Set("b", 1).Set("c", 0)) {
+			t.Error("Dicts with a missing key holding a zero value should not be equal.")
+		}
+		shared := map[string]int{"a": 1}
+		d := NewDictFrom(shared)
+		if !d.Equals(d) || !d.Equals(NewDictFrom(shared)) {
+			t.Error("Dicts sharing the same map should be equal.")
+		}
+		if !NewDict[string, int]().Equals(NewDict[string, int]()) {
+			t.Error("Empty dicts should be equal.")
+		}
 	})
 
 	t.Run("constructors", func(t *testing.T) {
 		if !NewDictFrom(map[string]int{"first": 1, "second": 2}).Equals(NewDict[string, int]().Set("first", 1).Set("second", 2)) {
 			t.Error("DictFrom does not work properly.")
 		}
+		withCapacity := NewDictWithCapacity[int, int](1000)
+		if !withCapacity.Empty() {
+			t.Error("DictWithCapacity should be empty.")
+		}
+		without := NewDict[int, int]()
+		for i := 0; i < 1000; i++ {
+			withCapacity.Set(i, i*i)
+			without.Set(i, i*i)
+		}
+		if !withCapacity.Equals(without) || withCapacity.Count() != 1000 {
+			t.Error("DictWithCapacity does not work properly.")
+		}
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		build := func() Dict[string, int] {
+			d := NewDictDeterministic[string, int]()
+			for i := 0; i < 50; i++ {
+				d.Set("key"+strconv.Itoa(i), i)
+			}
+			return d
+		}
+		if build().String() != build().String() {
+			t.Error("Serialization of deterministic dicts should be stable.")
+		}
+		d := NewDictDeterministic[string, int]().
+			Set("c", 3).
+			Set("a", 1).
+			Set("b", 2).
+			Set("c", 4)
+		if d.String() != `{"c":4,"a":1,"b":2}` {
+			t.Error("Deterministic dict should keep the insertion order.")
+		}
+		d.Unset("c").Set("c", 3)
+		if !d.Keys().Equals(NewList("a", "b", "c")) || !d.Values().Equals(NewList(1, 2, 3)) {
+			t.Error("Re-set key should be moved to the end.")
+		}
+		keys, values := d.KeysSlice(), d.ValuesSlice()
+		keys[0], values[0] = "x", 9
+		d.Keys().Add("y")
+		if !d.Keys().Equals(NewList("a", "b", "c")) || !d.Values().Equals(NewList(1, 2, 3)) || d.KeyExists("x") {
+			t.Error("Exported keys and values should be copies.")
+		}
+		visited := NewList[string]()
+		d.ForEach(func(key string, _ int) { visited.Add(key) })
+		if !visited.Equals(NewList("a", "b", "c")) {
+			t.Error("ForEach should follow the insertion order.")
+		}
+		if d.Clone().String() != d.String() || d.Map(func(_ string, v int) int { return v }).String() != d.String() ||
+			d.MapParallel(func(_ string, v int) int { return v }, 2).String() != d.String() {
+			t.Error("Copies of a deterministic dict should keep the order.")
+		}
+		if d.Pluck("c", "a").String() != `{"c":3,"a":1}` {
+			t.Error("Plucked deterministic dict should follow the order of the keys.")
+		}
+		if d.CloneExcept("b").String() != `{"a":1,"c":3}` {
+			t.Error("CloneExcept of a deterministic dict should keep the order.")
+		}
+		if !d.Clear().Set("z", 0).Keys().Equals(NewList("z")) {
+			t.Error("Clearing a deterministic dict should reset the order.")
+		}
 	})
 
 	t.Run("export", func(t *testing.T) {
@@ -91,6 +353,32 @@ func TestDict(t *testing.T) {
 		}
 	})
 
+	t.Run("deepCloning", func(t *testing.T) {
+		nested := NewList(1, 2)
+		inner := NewDict[string, List[int]]().Set("list", nested)
+		d := NewDict[string, Dict[string, List[int]]]().Set("inner", inner)
+		clone := d.DeepClone()
+		clone.Get("inner").Get("list").Add(3)
+		clone.Get("inner").Set("another", NewList[int]())
+		if !nested.Equals(NewList(1, 2)) || inner.Count() != 1 {
+			t.Error("Mutating a deep clone should not affect the original.")
+		}
+		if !clone.Get("inner").Get("list").Equals(NewList(1, 2, 3)) {
+			t.Error("Deep clone does not work properly.")
+		}
+		shallow := d.Clone()
+		shallow.Get("inner").Get("list").Add(3)
+		if !nested.Equals(NewList(1, 2, 3)) {
+			t.Error("Clone should copy nested collections by reference.")
+		}
+		mixed := NewDict[string, any]().Set("list", NewList[any](NewList(1))).Set("number", 1).Set("nil", nil)
+		mixedClone := mixed.DeepClone()
+		mixedClone.Get("list").(List[any]).Get(0).(List[int]).Add(2)
+		if mixed.Get("list").(List[any]).Get(0).(List[int]).Count() != 1 || mixedClone.Get("number") != 1 {
+			t.Error("Deep clone of untyped values does not work properly.")
+		}
+	})
+
 	t.Run("functional", func(t *testing.T) {
 		d := NewDict[string, int]().
 			Set("first", 1).
@@ -104,6 +392,126 @@ func TestDict(t *testing.T) {
 		if !d.Map(func(key string, value int) int { return value }).Equals(d) {
 			t.Error("Map does not work properly.")
 		}
+		keys := NewList[string]()
+		sum := 0
+		d.ForEachKey(func(key string) { keys.Add(key) }).ForEachValue(func(value int) { sum += value })
+		if keys.Count() != 3 || !keys.Contains("second") || sum != 6 {
+			t.Error("ForEachKey or ForEachValue does not work properly.")
+		}
+		sorted := NewList[string]()
+		d.Set("a", 0).ForEachSorted(func(key string, value int) { sorted.Add(key + strconv.Itoa(value)) }).Unset("a")
+		if !sorted.Equals(NewList("a0", "first1", "second2", "third3")) {
+			t.Error("ForEachSorted does not work properly.")
+		}
+		inPlace := d.Clone()
+		if inPlace.MapValuesInPlace(func(value int) int { return value * 2 }) != inPlace {
+			t.Error("MapValuesInPlace should return the receiver.")
+		}
+		if !inPlace.Equals(d.Map(func(_ string, value int) int { return value * 2 })) || !inPlace.Keys().SortClone().Equals(d.Keys().SortClone()) {
+			t.Error("MapValuesInPlace does not work properly.")
+		}
+		mapped := d.Clone()
+		suffix := func(key string, value int) int { return value*10 + len(key) }
+		if mapped.MapInPlace(suffix) != mapped || !mapped.Equals(d.Map(suffix)) || !mapped.Keys().SortClone().Equals(d.Keys().SortClone()) {
+			t.Error("MapInPlace does not work properly.")
+		}
+		big := NewDict[int, int]()
+		for i := 0; i < 1000; i++ {
+			big.Set(i, i)
+		}
+		square := func(key int, value int) int { return key * value }
+		for _, workers := range []int{1, 3, 0, -1} {
+			if !big.MapParallel(square, workers).Equals(big.Map(square)) {
+				t.Errorf("MapParallel with %d workers should be equal to Map.", workers)
+			}
+		}
+		if !NewDict[int, int]().MapParallel(square, 4).Empty() {
+			t.Error("MapParallel of an empty dict should be empty.")
+		}
+		audited := NewDict[string, int]()
+		tapped := d.
+			Tap(func(key string, value int) { audited.Set(key, value) }).
+			Map(func(key string, value int) int { return -value })
+		if !audited.Equals(d) || tapped.Get("third") != -3 {
+			t.Error("Tap does not work properly.")
+		}
+		withDefaults := func(d Dict[string, int]) Dict[string, int] {
+			return NewDict[string, int]().Set("fourth", 4).Merge(d)
+		}
+		piped := d.
+			Map(func(key string, value int) int { return value * 10 }).
+			Pipe(withDefaults).
+			Map(func(key string, value int) int { return value + 1 })
+		if !piped.Equals(NewDictFrom(map[string]int{"first": 11, "second": 21, "third": 31, "fourth": 5})) {
+			t.Error("Pipe does not work properly.")
+		}
+		var inspected Dict[string, int]
+		result := d.
+			Map(func(key string, value int) int { return value * 10 }).
+			Inspect(func(d Dict[string, int]) {
+				inspected = d
+				d.Set("fourth", 40)
+			}).
+			Map(func(key string, value int) int { return value + 1 })
+		if inspected.Get("first") != 10 || inspected.Count() != 4 || result.Get("fourth") != 41 {
+			t.Error("Inspect should pass the intermediate dict itself and keep its modifications.")
+		}
+		if d.Inspect(func(Dict[string, int]) {}) != d {
+			t.Error("Inspect should return the receiver.")
+		}
+	})
+
+	t.Run("sample", func(t *testing.T) {
+		d := NewDict[string, int]().Set("a", 1).Set("b", 2).Set("c", 3).Set("d", 4)
+		r := rand.New(rand.NewSource(42))
+		counts := map[string]int{}
+		for i := 0; i < 10000; i++ {
+			counts[d.SampleKey(r)]++
+		}
+		for key, count := range counts {
+			if count < 2300 || count > 2700 {
+				t.Errorf("Key %s has been sampled %d times out of 10000.", key, count)
+			}
+		}
+		if len(counts) != 4 {
+			t.Error("All keys should be sampled.")
+		}
+		if key, value := d.SampleEntry(nil); d.Get(key) != value {
+			t.Error("SampleEntry should return a field of the dict.")
+		}
+		first := d.SampleKey(rand.New(rand.NewSource(7)))
+		for i := 0; i < 10; i++ {
+			if NewDictFrom(d.GoMap()).SampleKey(rand.New(rand.NewSource(7))) != first {
+				t.Fatal("Seeded sampling should be reproducible.")
+			}
+		}
+		pairs := map[string]int{}
+		for i := 0; i < 6000; i++ {
+			sample := d.SampleN(2, r)
+			if sample.Count() != 2 {
+				t.Fatal("SampleN should return n fields.")
+			}
+			keys := sample.SortedKeys()
+			if d.Get(keys.Get(0)) != sample.Get(keys.Get(0)) {
+				t.Fatal("SampleN should return fields of the dict.")
+			}
+			pairs[keys.Get(0)+keys.Get(1)]++
+		}
+		for pair, count := range pairs {
+			if count < 850 || count > 1150 {
+				t.Errorf("Pair %s has been sampled %d times out of 6000.", pair, count)
+			}
+		}
+		if len(pairs) != 6 {
+			t.Error("All pairs should be sampled.")
+		}
+		ordered := NewDictDeterministic[string, int]().Set("z", 0).Set("y", 1).Set("x", 2)
+		if sample := ordered.SampleN(2, r); !sample.Keys().Equals(ordered.Keys().Filter(sample.KeyExists)) {
+			t.Error("SampleN of a deterministic dict should keep the order.")
+		}
+		if clone := ordered.SampleN(5, nil); clone.String() != ordered.String() || clone == ordered || !d.SampleN(0, nil).Empty() {
+			t.Error("SampleN over the count should return a copy.")
+		}
 	})
 
 }
@@ -160,6 +568,82 @@ func TestList(t *testing.T) {
 		if !l.Clone().Reverse().Equals(NewList(3, 2, 1)) {
 			t.Error("Reversing does not work properly.")
 		}
+		reversed := NewList(1, 2, 3)
+		if reversed.Reverse() != reversed || !reversed.Equals(NewList(3, 2, 1)) {
+			t.Error("Reverse should modify the receiver.")
+		}
+		if !reversed.ReverseClone().Equals(NewList(1, 2, 3)) || !reversed.Equals(NewList(3, 2, 1)) {
+			t.Error("ReverseClone should not modify the receiver.")
+		}
+	})
+
+	t.Run("padding", func(t *testing.T) {
+		l := NewList(1, 2, 3)
+		if !l.PadRight(2, 0).Equals(NewList(1, 2, 3)) || !l.PadLeft(3, 0).Equals(NewList(1, 2, 3)) {
+			t.Error("Padding a list which is long enough should not change it.")
+		}
+		if !l.PadRight(5, 0).Equals(NewList(1, 2, 3, 0, 0)) {
+			t.Error("List has not been padded at the end properly.")
+		}
+		if !l.PadLeft(7, 9).Equals(NewList(9, 9, 1, 2, 3, 0, 0)) {
+			t.Error("List has not been padded at the beginning properly.")
+		}
+		if !NewList[string]().PadLeft(2, "-").Equals(NewList("-", "-")) || !NewList[string]().PadRight(1, "-").Equals(NewList("-")) {
+			t.Error("Empty list has not been padded properly.")
+		}
+		if !l.Resize(3, 0).Equals(NewList(9, 9, 1)) {
+			t.Error("List has not been truncated properly.")
+		}
+		if !l.Resize(4, 5).Equals(NewList(9, 9, 1, 5)) {
+			t.Error("List has not been extended properly.")
+		}
+		if !l.Resize(4, 0).Equals(NewList(9, 9, 1, 5)) || !l.Resize(0, 0).Empty() {
+			t.Error("List has not been resized properly.")
+		}
+	})
+
+	t.Run("fill", func(t *testing.T) {
+		l := NewList(1, 2, 3)
+		if l.Fill(7) != l || l.Count() != 3 {
+			t.Error("Fill should keep the length and return the receiver.")
+		}
+		l.ForEach(func(value int) {
+			if value != 7 {
+				t.Errorf("Fill left value %d in the list.", value)
+			}
+		})
+		if !NewList[int]().Fill(7).Empty() {
+			t.Error("Fill of an empty list should do nothing.")
+		}
+	})
+
+	t.Run("replacing", func(t *testing.T) {
+		l := NewList(1, 2, 1, 3, 1)
+		if l.ReplaceAll(4, 0) != l || !l.Equals(NewList(1, 2, 1, 3, 1)) {
+			t.Error("ReplaceAll without matches should not change the list.")
+		}
+		if !l.ReplaceAll(1, 0).Equals(NewList(0, 2, 0, 3, 0)) || l.CountOf(0) != 3 || l.CountOf(1) != 0 {
+			t.Error("ReplaceAll should replace all occurrences including the edges.")
+		}
+		if !l.ReplaceWhere(func(value int) bool { return value > 0 }, 7).Equals(NewList(0, 7, 0, 7, 0)) {
+			t.Error("ReplaceWhere does not work properly.")
+		}
+		if !l.ReplaceWhere(func(int) bool { return true }, 5).Equals(NewListOf(5, 5)) || l.CountOf(5) != 5 {
+			t.Error("ReplaceWhere should replace all matching elements.")
+		}
+		original := NewList(1, 2, 3, 2)
+		if c := original.ReplaceAllClone(9, 0); !c.Equals(original) || c == original {
+			t.Error("ReplaceAllClone without matches should return a copy.")
+		}
+		if !original.ReplaceAllClone(1, 0).Equals(NewList(0, 2, 3, 2)) || !original.ReplaceAllClone(2, 0).Equals(NewList(1, 0, 3, 0)) {
+			t.Error("ReplaceAllClone does not work properly.")
+		}
+		if !original.Equals(NewList(1, 2, 3, 2)) {
+			t.Error("ReplaceAllClone should not modify the list.")
+		}
+		if !NewList[int]().ReplaceAll(1, 2).Empty() || NewList[int]().CountOf(1) != 0 {
+			t.Error("Replacing in an empty list should do nothing.")
+		}
 	})
 
 	t.Run("equality", func(t *testing.T) {
@@ -178,6 +662,13 @@ func TestList(t *testing.T) {
 		if !NewListFrom(make([]int, 3)).Equals(NewList(0, 0, 0)) {
 			t.Error("ListFrom does not work properly.")
 		}
+		withCapacity := NewListWithCapacity[int](2)
+		if !withCapacity.Empty() {
+			t.Error("ListWithCapacity should be empty.")
+		}
+		if !withCapacity.Add(1, 2).Equals(NewList(1, 2)) || !withCapacity.Add(3).Equals(NewList(1, 2, 3)) {
+			t.Error("ListWithCapacity does not work properly.")
+		}
 	})
 
 	t.Run("export", func(t *testing.T) {
@@ -241,6 +732,266 @@ func TestList(t *testing.T) {
 		}
 	})
 
+	t.Run("serializationSpecial", func(t *testing.T) {
+		moment := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+		if NewList(moment).String() != `["2024-01-02T15:04:05Z"]` {
+			t.Error("Serialization of time does not work properly.")
+		}
+		now := time.Now()
+		var decoded []time.Time
+		if err := json.Unmarshal([]byte(NewList(now).String()), &decoded); err != nil || len(decoded) != 1 || !decoded[0].Equal(now) {
+			t.Errorf("Current time should be serialized as a valid RFC 3339 string with nanoseconds, got %s.", NewList(now))
+		}
+		if NewList(moment.Add(time.Nanosecond).In(time.FixedZone("", 3600))).String() != `["2024-01-02T16:04:05.000000001+01:00"]` {
+			t.Error("Serialization of time should keep the fraction of a second and the offset.")
+		}
+		if NewList(90*time.Second).String() != `["1m30s"]` {
+			t.Error("Serialization of duration does not work properly.")
+		}
+		if NewList[any]([]byte("hi")).String() != `["aGk="]` {
+			t.Error("Serialization of byte slice does not work properly.")
+		}
+		encoded, _ := json.Marshal([]any{[]byte("hello"), []byte{}})
+		if result := NewList[any]([]byte("hello"), []byte{}).String(); result != `["aGVsbG8=",""]` || result != string(encoded) {
+			t.Errorf("Byte slices should be encoded to base64 as encoding/json does, got %s.", result)
+		}
+		var object map[string]any
+		json.Unmarshal([]byte(`{"b":[1,"x",{"c":null}],"a":{"d":true}}`), &object)
+		if result := NewDict[string, any]().Set("data", object).String(); result != `{"data":{"a":{"d":true},"b":[1,"x",{"c":null}]}}` {
+			t.Errorf("Serialization of decoded Go maps does not work properly, got %s.", result)
+		}
+		if result := NewList[any](map[string]string{"k": "v"}, map[string]int{"y": 2, "x": 1}, map[string]any{}).String(); result != `[{"k":"v"},{"x":1,"y":2},{}]` {
+			t.Errorf("Serialization of Go maps does not work properly, got %s.", result)
+		}
+		if NewList(stringer{"say \"hi\""}).String() != `["say \"hi\""]` {
+			t.Error("Output of a Stringer should be quoted.")
+		}
+		d := NewDictDeterministic[string, any]().
+			Set("time", moment).
+			Set("duration", time.Millisecond).
+			Set("bytes", []byte("hi")).
+			Set("list", NewList(moment))
+		if d.String() != `{"time":"2024-01-02T15:04:05Z","duration":"1ms","bytes":"aGk=","list":["2024-01-02T15:04:05Z"]}` {
+			t.Error("Serialization of special types inside a dict does not work properly.")
+		}
+		if !json.Valid([]byte(NewList[any](d, moment, []byte{0, 255}, time.Hour).String())) {
+			t.Error("Serialization of special types should produce a valid JSON.")
+		}
+	})
+
+	t.Run("jsonString", func(t *testing.T) {
+		roundTrip := func(jsonString func() (string, error), target any) {
+			t.Helper()
+			out, err := jsonString()
+			if err != nil {
+				t.Fatalf("JSONString failed: %v", err)
+			}
+			if err := json.Unmarshal([]byte(out), target); err != nil {
+				t.Fatalf("JSONString output %s is not a valid JSON: %v", out, err)
+			}
+		}
+		var ints []int64
+		roundTrip(NewList[int64](math.MinInt64, 0, math.MaxInt64).JSONString, &ints)
+		if !NewListFrom(ints).Equals(NewList[int64](math.MinInt64, 0, math.MaxInt64)) {
+			t.Error("Round trip of int64 does not work properly.")
+		}
+		var small []int8
+		roundTrip(NewList[int8](-128, 127).JSONString, &small)
+		if !NewListFrom(small).Equals(NewList[int8](-128, 127)) {
+			t.Error("Round trip of int8 does not work properly.")
+		}
+		var uints []uint64
+		roundTrip(NewList[uint64](0, math.MaxUint64).JSONString, &uints)
+		if !NewListFrom(uints).Equals(NewList[uint64](0, math.MaxUint64)) {
+			t.Error("Round trip of uint64 does not work properly.")
+		}
+		var floats []float64
+		roundTrip(NewList(-0.5, 1e300, math.SmallestNonzeroFloat64).JSONString, &floats)
+		if !NewListFrom(floats).Equals(NewList(-0.5, 1e300, math.SmallestNonzeroFloat64)) {
+			t.Error("Round trip of float64 does not work properly.")
+		}
+		var floats32 []float32
+		roundTrip(NewList[float32](3.14, -1e30).JSONString, &floats32)
+		if !NewListFrom(floats32).Equals(NewList[float32](3.14, -1e30)) {
+			t.Error("Round trip of float32 does not work properly.")
+		}
+		var strs []string
+		roundTrip(NewList("quote \"", "tab\t", "<html>", "čšř", "\u2028").JSONString, &strs)
+		if !NewListFrom(strs).Equals(NewList("quote \"", "tab\t", "<html>", "čšř", "\u2028")) {
+			t.Error("Round trip of strings does not work properly.")
+		}
+		var bools []bool
+		roundTrip(NewList(true, false).JSONString, &bools)
+		if !NewListFrom(bools).Equals(NewList(true, false)) {
+			t.Error("Round trip of bools does not work properly.")
+		}
+		var mixed []any
+		roundTrip(NewList[any](nil, "a", 1, true, NewList(1, 2), NewDict[string, any]().Set("k", nil)).JSONString, &mixed)
+		if fmt.Sprint(mixed) != "[<nil> a 1 true [1 2] map[k:<nil>]]" {
+			t.Errorf("Round trip of mixed values does not work properly: %v", mixed)
+		}
+		if out, _ := NewList[int]().JSONString(); out != `[]` {
+			t.Error("Empty list should be serialized as an empty array.")
+		}
+		if _, err := NewList(math.NaN()).JSONString(); err == nil {
+			t.Error("NaN cannot be encoded and should produce an error.")
+		}
+		if _, err := NewList[any](NewList[any](func() {})).JSONString(); err == nil {
+			t.Error("Nested function cannot be encoded and should produce an error.")
+		}
+		if out, err := json.Marshal(map[string]any{"list": NewList(1, 2)}); err != nil || string(out) != `{"list":[1,2]}` {
+			t.Error("Nested list should be encoded by encoding/json as an array.")
+		}
+	})
+
+	t.Run("serializationOptions", func(t *testing.T) {
+		l := NewList(1, 2, 3)
+		if l.StringWithOptions("[", "]", ",") != l.String() {
+			t.Error("Default options should be equal to String.")
+		}
+		if l.StringWithOptions("(", ")", ", ") != `(1, 2, 3)` {
+			t.Error("Serialization with custom wrapper and separator does not work properly.")
+		}
+		if l.StringWithOptions("", "", " | ") != `1 | 2 | 3` {
+			t.Error("Serialization with custom separator does not work properly.")
+		}
+		if l.StringWithOptions("{", "}", ",") != `{1,2,3}` {
+			t.Error("Serialization with custom wrapper does not work properly.")
+		}
+		if NewList[int]().StringWithOptions("(", ")", ", ") != `()` {
+			t.Error("Serialization of an empty list does not work properly.")
+		}
+		if NewList(NewList(1, 2), NewList(3)).StringWithOptions("(", ")", "; ") != `([1,2]; [3])` {
+			t.Error("Nested lists should be serialized with default options.")
+		}
+	})
+
+	t.Run("bulk", func(t *testing.T) {
+		l := NewList(1, 2)
+		if !l.AddList(NewList(3, 4)).Equals(NewList(1, 2, 3, 4)) {
+			t.Error("AddList does not work properly.")
+		}
+		if !l.AddSlice([]int{5, 6}).Equals(NewList(1, 2, 3, 4, 5, 6)) {
+			t.Error("AddSlice does not work properly.")
+		}
+		if !l.PrependList(NewList(-1, 0)).Equals(NewList(-1, 0, 1, 2, 3, 4, 5, 6)) {
+			t.Error("PrependList does not work properly.")
+		}
+		if !NewList(1).AddList(NewList[int]()).AddSlice(nil).PrependList(NewList[int]()).Equals(NewList(1)) {
+			t.Error("Adding empty collections should not change the list.")
+		}
+		self := NewList(1, 2)
+		if !self.AddList(self).Equals(NewList(1, 2, 1, 2)) {
+			t.Error("AddList should be able to append the list to itself.")
+		}
+	})
+
+	t.Run("deepCloning", func(t *testing.T) {
+		inner := NewList(1, 2)
+		l := NewList(inner, NewList(3))
+		clone := l.DeepClone()
+		clone.Get(0).Add(5)
+		if !inner.Equals(NewList(1, 2)) || !clone.Get(0).Equals(NewList(1, 2, 5)) {
+			t.Error("Mutating a deep clone should not affect the original.")
+		}
+		l.Clone().Get(0).Add(4)
+		if !inner.Equals(NewList(1, 2, 4)) {
+			t.Error("Clone should copy nested lists by reference.")
+		}
+		dicts := NewList(NewDict[string, int]().Set("first", 1))
+		dicts.DeepClone().Get(0).Set("second", 2)
+		if dicts.Get(0).Count() != 1 {
+			t.Error("Deep clone should copy nested dicts.")
+		}
+		flat := NewList(1, 2, 3)
+		flatClone := flat.DeepClone()
+		flatClone.Replace(0, 0)
+		if !flat.Equals(NewList(1, 2, 3)) || !flatClone.Equals(NewList(0, 2, 3)) {
+			t.Error("Deep clone of a flat list does not work properly.")
+		}
+	})
+
+	t.Run("concat", func(t *testing.T) {
+		l1 := NewListFrom(make([]int, 2, 10))
+		l2 := NewList(1, 2)
+		l3 := NewList(3)
+		result := l1.Concat(l2, nil, l3)
+		if !result.Equals(NewList(0, 0, 1, 2, 3)) {
+			t.Error("Concatenation of three lists does not work properly.")
+		}
+		result.Replace(0, 5).Add(6)
+		if !l1.Equals(NewList(0, 0)) || !l2.Equals(NewList(1, 2)) || !l3.Equals(NewList(3)) {
+			t.Error("Concatenation should not change the original lists.")
+		}
+		if l1.GoSlice()[:3][2] != 0 {
+			t.Error("Concatenation should not write to the backing array of the receiver.")
+		}
+		if !l1.Concat().Equals(l1) {
+			t.Error("Concatenation without arguments should return a copy.")
+		}
+		if copied := l2.Concat(); copied.Add(9) == l2 || l2.Count() != 2 {
+			t.Error("Concatenation without arguments should not return the receiver.")
+		}
+		if !l2.Concat(l3).Equals(NewList(1, 2, 3)) ||
+			!l2.Concat(l3, l2).Equals(NewList(1, 2, 3, 1, 2)) ||
+			!l2.Concat(l3, l2, l3).Equals(NewList(1, 2, 3, 1, 2, 3)) {
+			t.Error("Concatenation of multiple lists does not work properly.")
+		}
+		chunks := make([]List[int], 100)
+		for i := range chunks {
+			chunks[i] = NewListOf(i, 1000)
+		}
+		large := NewList[int]().Concat(chunks...)
+		if large.Count() != 100000 || large.Get(0) != 0 || large.Get(99999) != 99 || large.Get(50000) != 50 {
+			t.Error("Large-scale concatenation does not work properly.")
+		}
+	})
+
+	t.Run("repeat", func(t *testing.T) {
+		l := NewList(1, 2)
+		if repeated := l.Repeat(3); repeated.Count() != 6 || !repeated.Equals(NewList(1, 2, 1, 2, 1, 2)) {
+			t.Error("Repeat does not work properly.")
+		}
+		if once := l.Repeat(1); !once.Equals(l) || once == l {
+			t.Error("Repeat once should return a copy.")
+		}
+		if !l.Repeat(0).Empty() || !l.Equals(NewList(1, 2)) {
+			t.Error("Repeat zero times should return an empty list.")
+		}
+	})
+
+	t.Run("symmetricDifference", func(t *testing.T) {
+		a, b := NewList(1, 2, 3, 4), NewList(3, 4, 5, 6, 5)
+		if !a.SymmetricDifference(b).Equals(NewList(1, 2, 5, 6)) {
+			t.Error("SymmetricDifference does not work properly.")
+		}
+		if !ToSet(a.SymmetricDifference(b)).Equals(ToSet(b.SymmetricDifference(a))) {
+			t.Error("SymmetricDifference should be commutative.")
+		}
+		if !a.SymmetricDifference(a).Empty() || !a.SymmetricDifference(a.Clone().Reverse()).Empty() {
+			t.Error("SymmetricDifference of identical lists should be empty.")
+		}
+		if !a.SymmetricDifference(NewList[int]()).Equals(a) || !NewList[int]().SymmetricDifference(b).Equals(NewList(3, 4, 5, 6)) {
+			t.Error("SymmetricDifference with an empty list does not work properly.")
+		}
+		if !NewList(1, 1, 2).SymmetricDifference(NewList(2, 2)).Equals(NewList(1)) {
+			t.Error("SymmetricDifference should include each element once.")
+		}
+		if !a.Equals(NewList(1, 2, 3, 4)) || !b.Equals(NewList(3, 4, 5, 6, 5)) {
+			t.Error("SymmetricDifference should not modify the lists.")
+		}
+	})
+
+	t.Run("intersperse", func(t *testing.T) {
+		l := NewList("home", "docs", "api")
+		if !l.Intersperse(">").Equals(NewList("home", ">", "docs", ">", "api")) || l.Count() != 3 {
+			t.Error("Intersperse does not work properly.")
+		}
+		if !NewList(1).Intersperse(0).Equals(NewList(1)) || !NewList[int]().Intersperse(0).Empty() {
+			t.Error("Intersperse of a short list should not add separators.")
+		}
+	})
+
 	t.Run("sublist", func(t *testing.T) {
 		l := NewList(0, 1, 2, 3, 4)
 		if !l.SubList(0, 0).Equals(l) {
@@ -252,6 +1003,71 @@ func TestList(t *testing.T) {
 		if !l.SubList(0, -2).Equals(NewList(0, 1, 2)) {
 			t.Error("SubList(0, -2) should cut last two elements.")
 		}
+		if !l.SubListFrom(2).Equals(NewList(2, 3, 4)) {
+			t.Error("SubListFrom(2) should return elements from index 2 to the end.")
+		}
+		if !l.SubListFrom(-2).Equals(NewList(3, 4)) {
+			t.Error("SubListFrom(-2) should return last two elements.")
+		}
+		if !l.SubListTo(2).Equals(NewList(0, 1)) || !l.SubListTo(-2).Equals(NewList(0, 1, 2)) {
+			t.Error("SubListTo should return elements from the beginning to the index.")
+		}
+		if !l.SubListTo(0).Empty() || !l.SubListTo(l.Count()).Equals(l) {
+			t.Error("SubListTo(0) should return an empty list.")
+		}
+	})
+
+	t.Run("sublistSigns", func(t *testing.T) {
+		l := NewList(0, 1, 2, 3, 4)
+		cases := []struct {
+			start, end int
+			result     List[int]
+		}{
+			{1, 3, NewList(1, 2)},
+			{1, 0, NewList(1, 2, 3, 4)},
+			{1, -1, NewList(1, 2, 3)},
+			{0, 3, NewList(0, 1, 2)},
+			{0, 0, NewList(0, 1, 2, 3, 4)},
+			{0, -1, NewList(0, 1, 2, 3)},
+			{-3, 4, NewList(2, 3)},
+			{-3, 0, NewList(2, 3, 4)},
+			{-3, -1, NewList(2, 3)},
+			{5, 0, NewList[int]()},
+			{-5, 5, NewList(0, 1, 2, 3, 4)},
+		}
+		for _, c := range cases {
+			if !l.SubList(c.start, c.end).Equals(c.result) {
+				t.Errorf("SubList(%d, %d) should return %s.", c.start, c.end, c.result)
+			}
+		}
+	})
+
+	t.Run("split", func(t *testing.T) {
+		l := NewList(1, 2, 3, 4, 5)
+		head, tail := l.SplitAt(2)
+		if !head.Equals(NewList(1, 2)) || !tail.Equals(NewList(3, 4, 5)) {
+			t.Error("SplitAt does not work properly.")
+		}
+		head.Add(9)
+		tail.Replace(0, 9)
+		if !l.Equals(NewList(1, 2, 3, 4, 5)) {
+			t.Error("Split lists should not share memory with the list.")
+		}
+		if head, tail := l.SplitAt(0); !head.Empty() || !tail.Equals(l) {
+			t.Error("SplitAt the beginning should give an empty first list.")
+		}
+		if head, tail := l.SplitAt(5); !head.Equals(l) || !tail.Empty() {
+			t.Error("SplitAt the end should give an empty second list.")
+		}
+		if left, right := l.Halve(); !left.Equals(NewList(1, 2)) || !right.Equals(NewList(3, 4, 5)) {
+			t.Error("Halve of an odd-length list does not work properly.")
+		}
+		if left, right := NewList(1, 2).Halve(); !left.Equals(NewList(1)) || !right.Equals(NewList(2)) {
+			t.Error("Halve of an even-length list does not work properly.")
+		}
+		if left, right := NewList[int]().Halve(); !left.Empty() || !right.Empty() {
+			t.Error("Halves of an empty list should be empty.")
+		}
 	})
 
 	t.Run("functional", func(t *testing.T) {
@@ -261,15 +1077,132 @@ func TestList(t *testing.T) {
 		if !t1.Equals(l) {
 			t.Error("ForEach does not work properly.")
 		}
+		indexes := NewList[int]()
+		l.ForEachIndexed(func(i int, value int) {
+			indexes.Add(i)
+			if value != l.Get(i) {
+				t.Errorf("ForEachIndexed passed value %d at index %d.", value, i)
+			}
+		})
+		if !indexes.Equals(NewList(0, 1, 2, 3, 4)) {
+			t.Error("ForEachIndexed should pass sequential indexes starting from 0.")
+		}
+		batches := func(l List[int], size int) string {
+			sizes := NewList[int]()
+			joined := NewList[int]()
+			l.ForEachBatched(size, func(batch List[int]) {
+				sizes.Add(batch.Count())
+				joined.AddList(batch)
+			})
+			if !joined.Equals(l) {
+				t.Errorf("Batches of %s should cover the whole list in order.", l)
+			}
+			return sizes.String()
+		}
+		if batches(NewList(1, 2, 3, 4, 5, 6), 2) != "[2,2,2]" || batches(NewList(1, 2, 3, 4, 5, 6), 6) != "[6]" {
+			t.Error("ForEachBatched with an even split does not work properly.")
+		}
+		if batches(l, 2) != "[2,2,1]" || batches(l, 10) != "[5]" || batches(l, 1) != "[1,1,1,1,1]" {
+			t.Error("ForEachBatched with an uneven split does not work properly.")
+		}
+		if batches(NewList[int](), 3) != "[]" {
+			t.Error("ForEachBatched over an empty list should not call the function.")
+		}
 		if !l.Map(func(value int) int { return value }).Equals(l) {
 			t.Error("Map does not work properly.")
 		}
+		double := func(value int) int { return value * 2 }
+		inPlace := l.Clone()
+		if inPlace.MapInPlace(double) != inPlace || !inPlace.Equals(l.Map(double)) || !l.Equals(NewList(1, 2, 3, 4, 5)) {
+			t.Error("MapInPlace does not work properly.")
+		}
+		big := NewList[int]()
+		for i := 0; i < 1000; i++ {
+			big.Add(i)
+		}
+		square := func(value int) int { return value * value }
+		for _, workers := range []int{1, 3, 7, 0, -1, 5000} {
+			if !big.MapParallel(square, workers).Equals(big.Map(square)) {
+				t.Errorf("MapParallel with %d workers should be equal to Map.", workers)
+			}
+		}
+		if !NewList[int]().MapParallel(square, 4).Empty() {
+			t.Error("MapParallel of an empty list should be empty.")
+		}
 		if l.Reduce(0, func(sum, x int) int { return sum + x }) != 15 {
 			t.Error("Reduce does not work properly.")
 		}
+		if l.Fold(func(sum, x int) int { return sum + x }) != 15 ||
+			l.Fold(func(max, x int) int {
+				if x > max {
+					return x
+				}
+				return max
+			}) != 5 ||
+			NewList("a", "b", "c").Fold(func(res, x string) string { return res + x }) != "abc" ||
+			NewList(7).Fold(func(res, x int) int { return 0 }) != 7 {
+			t.Error("Fold does not work properly.")
+		}
 		if l.Filter(func(value int) bool { return value <= 3 }).Count() != 3 {
 			t.Error("Filter does not work properly.")
 		}
+		seen := NewList[int]()
+		tapped := l.
+			Filter(func(value int) bool { return value > 2 }).
+			Tap(func(value int) { seen.Add(value) }).
+			Map(func(value int) int { return -value })
+		if !seen.Equals(NewList(3, 4, 5)) || !tapped.Equals(NewList(-3, -4, -5)) {
+			t.Error("Tap does not work properly.")
+		}
+		var processed atomic.Int64
+		failures := 0
+		for err := range l.ForEachAsync(func(value int) error {
+			processed.Add(int64(value))
+			if value%2 == 0 {
+				return fmt.Errorf("even value %d", value)
+			}
+			return nil
+		}) {
+			if err == nil {
+				t.Error("ForEachAsync should send only non-nil errors.")
+			}
+			failures++
+		}
+		if processed.Load() != 15 || failures != 2 {
+			t.Error("ForEachAsync does not work properly.")
+		}
+		if _, ok := <-NewList[int]().ForEachAsync(func(int) error { return nil }); ok {
+			t.Error("ForEachAsync over an empty list should close the channel.")
+		}
+		piped := l.
+			Filter(func(value int) bool { return value%2 == 1 }).
+			Pipe(func(l List[int]) List[int] { return l.Reverse() }).
+			Map(func(value int) int { return value * 10 })
+		if !piped.Equals(NewList(50, 30, 10)) {
+			t.Error("Pipe does not work properly.")
+		}
+		calls := 0
+		inspected := l.
+			Filter(func(value int) bool { return value%2 == 1 }).
+			Inspect(func(l List[int]) {
+				calls++
+				if !l.Equals(NewList(1, 3, 5)) {
+					t.Errorf("Inspect should receive the intermediate list, got %s.", l)
+				}
+				l.Add(7)
+			}).
+			Map(func(value int) int { return value * 10 })
+		if calls != 1 || !inspected.Equals(NewList(10, 30, 50, 70)) || l.Inspect(func(List[int]) {}) != l {
+			t.Error("Modifications inside Inspect should be visible downstream.")
+		}
+		func() {
+			defer func() {
+				if recover() != "boom" {
+					t.Error("Inspect should not swallow panics.")
+				}
+			}()
+			l.Inspect(func(List[int]) { panic("boom") })
+		}()
 	})
 
 	t.Run("numeric", func(t *testing.T) {
@@ -291,18 +1224,42 @@ func TestList(t *testing.T) {
 		if NewList(1, 4, 5).Sum() != 10.0 {
 			t.Error("Int sum does not work.")
 		}
+		if NewList(1, 4, 5).SumTyped() != 10 || NewList(1.5, 2.5).SumTyped() != 4.0 || NewList[int]().SumTyped() != 0 {
+			t.Error("Typed sum does not work.")
+		}
+		if large := NewList(1<<60, 1, 1<<60); large.SumTyped() != 1<<61+1 || int(large.Sum()) == 1<<61+1 {
+			t.Error("Typed sum of ints should not lose precision.")
+		}
 		if NewList(1.0, 4.0, 5.0).Prod() != 20.0 {
 			t.Error("Float prod does not work.")
 		}
 		if NewList(1, 4, 5).Prod() != 20.0 {
 			t.Error("Int prod does not work.")
 		}
+		if product := NewList(2, 3, 4).ProdTyped(); product != int(24) || NewList(0.5, 3.0).ProdTyped() != 1.5 || NewList[int]().ProdTyped() != 0 {
+			t.Error("Typed prod does not work.")
+		}
+		if large := NewList(1<<30+1, 1<<30-1); large.ProdTyped() != 1<<60-1 || int(large.Prod()) == 1<<60-1 {
+			t.Error("Typed prod of ints should not lose precision.")
+		}
 		if NewList(0.0, 5.0, 5.0, 10.0).Avg() != 5.0 {
 			t.Error("Float avg does not work.")
 		}
 		if NewList(0, 5, 5, 10).Avg() != 5.0 {
 			t.Error("Int avg does not work.")
 		}
+		if NewList(1, 3).Avg() != 2.0 || NewList(1, 2).Avg() != 1.5 {
+			t.Error("Avg should not truncate the result.")
+		}
+		if NewList(1, 2, 3).AvgInt() != 2 || NewList(1, 2).AvgInt() != 1 || NewList(-1, -2).AvgInt() != -1 || NewList(2.5, 4.0).AvgInt() != 3 {
+			t.Error("AvgInt does not work.")
+		}
+		if NewList(1<<60+1, 1<<60+3).AvgInt() != 1<<60+2 {
+			t.Error("AvgInt should not lose precision.")
+		}
+		if NewList[int]().AvgInt() != 0 {
+			t.Error("AvgInt of empty list does not return 0.")
+		}
 		emptyInt := NewList[int]()
 		if emptyInt.Min() != 0 {
 			t.Error("Min of empty list does not return 0.")
@@ -329,24 +1286,970 @@ func TestList(t *testing.T) {
 		if emptyFloat.Prod() != 0 {
 			t.Error("Prod of empty list does not return 0.")
 		}
-	})
-
-	t.Run("sorting", func(t *testing.T) {
-		if !NewList(2, 4, 3, 5, 1).Sort().Equals(NewList(1, 2, 3, 4, 5)) {
-			t.Error("Ascending int sorting does not work properly.")
+		if value, ok := NewList(2, -4, 3).TryMin(); !ok || value != -4 {
+			t.Error("TryMin does not work properly.")
 		}
-		if !NewList(2.0, 4.0, 3.0, 5.0, 1.0).Sort().Equals(NewList(1.0, 2.0, 3.0, 4.0, 5.0)) {
-			t.Error("Ascending float sorting does not work properly.")
+		if value, ok := NewList(0.5, 2.5, 1.0).TryMax(); !ok || value != 2.5 {
+			t.Error("TryMax does not work properly.")
 		}
-		if !NewList("b", "c", "a").Sort().Equals(NewList("a", "b", "c")) {
-			t.Error("Ascending string sorting does not work properly.")
+		if value, ok := NewList("b", "c", "a").TryMax(); !ok || value != "c" {
+			t.Error("TryMax of strings does not work properly.")
+		}
+		if value, ok := NewList(0, 1).TryMin(); !ok || value != 0 {
+			t.Error("TryMin should distinguish a zero minimum from an empty list.")
+		}
+		if _, ok := emptyInt.TryMin(); ok {
+			t.Error("TryMin of empty list should return false.")
+		}
+		if value, ok := emptyFloat.TryMax(); ok || value != 0 {
+			t.Error("TryMax of empty list should return zero value and false.")
 		}
 	})
 
-}
-
+	t.Run("vectors", func(t *testing.T) {
+		ints := NewList(2, 4, 6)
+		floats := NewList(0.5, 1.5, 1.0)
+		if !ints.Normalize().Equals(NewList(0.0, 0.5, 1.0)) || !floats.Normalize().Equals(NewList(0.0, 1.0, 0.5)) {
+			t.Error("Normalize does not work properly.")
+		}
+		if !NewList(3, 3).Normalize().Equals(NewList(0.0, 0.0)) || !NewList[int]().Normalize().Empty() {
+			t.Error("Normalize of equal elements should return zeros.")
+		}
+		if !ints.Scale(0.5).Equals(NewList(1.0, 2.0, 3.0)) || !floats.Scale(2).Equals(NewList(1.0, 3.0, 2.0)) {
+			t.Error("Scale does not work properly.")
+		}
+		if !ints.AddScalar(1).Equals(NewList(3.0, 5.0, 7.0)) || !floats.AddScalar(-0.5).Equals(NewList(0.0, 1.0, 0.5)) {
+			t.Error("AddScalar does not work properly.")
+		}
+		if !ints.AddElementwise(NewList(1, 2, 3)).Equals(NewList(3.0, 6.0, 9.0)) ||
+			!floats.AddElementwise(NewList(0.5, 0.5, 0.5)).Equals(NewList(1.0, 2.0, 1.5)) {
+			t.Error("AddElementwise does not work properly.")
+		}
+		if !ints.SubElementwise(NewList(1, 2, 3)).Equals(NewList(1.0, 2.0, 3.0)) {
+			t.Error("SubElementwise does not work properly.")
+		}
+		if !ints.MulElementwise(NewList(1, 2, 3)).Equals(NewList(2.0, 8.0, 18.0)) {
+			t.Error("MulElementwise does not work properly.")
+		}
+		if !ints.DivElementwise(NewList(1, 8, 3)).Equals(NewList(2.0, 0.5, 2.0)) || !math.IsInf(floats.DivElementwise(NewList(0.0, 1.0, 1.0)).Get(0), 1) {
+			t.Error("DivElementwise does not work properly.")
+		}
+		if ints.DotProduct(NewList(1, 2, 3)) != 28 || floats.DotProduct(floats) != 3.5 || NewList[int]().DotProduct(NewList[int]()) != 0 {
+			t.Error("DotProduct does not work properly.")
+		}
+		if !ints.Equals(NewList(2, 4, 6)) || !floats.Equals(NewList(0.5, 1.5, 1.0)) {
+			t.Error("Vector operations should not modify the lists.")
+		}
+	})
+
+	t.Run("histogram", func(t *testing.T) {
+		l := NewList(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+		if h := l.Histogram(2); h.String() != `{0:5,1:6}` {
+			t.Errorf("Histogram does not work properly, got %s.", h)
+		}
+		if h := l.Histogram(5); h.String() != `{0:2,1:2,2:2,3:2,4:3}` {
+			t.Errorf("Histogram does not work properly, got %s.", h)
+		}
+		if h := NewList(0.0, 0.1, 0.9, 1.0).Histogram(4); h.String() != `{0:2,1:0,2:0,3:2}` {
+			t.Errorf("Histogram should include empty bins, got %s.", h)
+		}
+		if h := NewList(7, 7, 7).Histogram(3); h.String() != `{0:3,1:0,2:0}` {
+			t.Errorf("Equal elements should be counted in the first bin, got %s.", h)
+		}
+		if h := NewList[float64]().Histogram(2); h.String() != `{0:0,1:0}` {
+			t.Errorf("Histogram of an empty list should contain empty bins, got %s.", h)
+		}
+		edges := NewList(0.0, 10.0, 100.0, 1000.0)
+		if h := NewList(-1, 0, 5, 10, 99, 100, 1000, 1001).HistogramEdges(edges); h.String() != `{0:2,1:2,2:2}` {
+			t.Errorf("HistogramEdges does not work properly, got %s.", h)
+		}
+		if h := NewList(2.5).HistogramEdges(NewList(2.5, 3.0)); h.Get(0) != 1 {
+			t.Error("Element on the first edge should be counted.")
+		}
+		if h := NewList(1.0, math.NaN(), 3.0).Histogram(2); h.String() != `{0:1,1:1}` {
+			t.Errorf("NaN should not be counted by Histogram, got %s.", h)
+		}
+		if h := NewList(math.Inf(-1), 1.0, 2.0, 3.0, math.Inf(1)).Histogram(2); h.String() != `{0:2,1:3}` {
+			t.Errorf("Infinities should be counted in the edge bins, got %s.", h)
+		}
+		if h := NewList(math.Inf(1), math.NaN()).Histogram(2); h.String() != `{0:0,1:1}` {
+			t.Errorf("List without finite elements should be counted properly, got %s.", h)
+		}
+		if h := NewList(1.0, math.NaN(), 3.0, math.Inf(1)).HistogramEdges(NewList(0.0, 2.0, 4.0)); h.String() != `{0:1,1:1}` {
+			t.Errorf("NaN and elements outside of the edges should not be counted by HistogramEdges, got %s.", h)
+		}
+	})
+
+	t.Run("sorting", func(t *testing.T) {
+		if !NewList(2, 4, 3, 5, 1).Sort().Equals(NewList(1, 2, 3, 4, 5)) {
+			t.Error("Ascending int sorting does not work properly.")
+		}
+		if !NewList(2.0, 4.0, 3.0, 5.0, 1.0).Sort().Equals(NewList(1.0, 2.0, 3.0, 4.0, 5.0)) {
+			t.Error("Ascending float sorting does not work properly.")
+		}
+		if !NewList("b", "c", "a").Sort().Equals(NewList("a", "b", "c")) {
+			t.Error("Ascending string sorting does not work properly.")
+		}
+		l := NewList(3, 1, 2)
+		if l.SortInPlace() != l {
+			t.Error("SortInPlace should return the receiver.")
+		}
+		if !l.Equals(NewList(1, 2, 3)) {
+			t.Error("SortInPlace should modify the receiver.")
+		}
+		unsorted := NewList("b", "c", "a")
+		sorted := unsorted.SortClone()
+		if !sorted.Equals(NewList("a", "b", "c")) || !unsorted.Equals(NewList("b", "c", "a")) {
+			t.Error("SortClone should not modify the receiver.")
+		}
+		if unsorted.Sort() != unsorted || !unsorted.Equals(sorted) {
+			t.Error("Sort should modify the receiver.")
+		}
+		records := NewList[Pair[int, int]]()
+		for i := 0; i < 200; i++ {
+			records.Add(NewPair((i*7)%5, i))
+		}
+		if records.SortFunc(func(a, b Pair[int, int]) int { return a.First() - b.First() }) != records {
+			t.Error("SortFunc should return the receiver.")
+		}
+		for i := 1; i < records.Count(); i++ {
+			previousKey, previous := Unpair(records.Get(i - 1))
+			key, payload := Unpair(records.Get(i))
+			if previousKey > key || previousKey == key && previous > payload {
+				t.Fatalf("SortFunc should be stable, got %v before %v.", records.Get(i-1), records.Get(i))
+			}
+		}
+		people := NewList(NewPair("bob", 30), NewPair("alice", 25), NewPair("carol", 30), NewPair("dave", 25))
+		people.
+			SortFunc(func(a, b Pair[string, int]) int { return strings.Compare(a.First(), b.First()) }).
+			SortFunc(func(a, b Pair[string, int]) int { return b.Second() - a.Second() })
+		if people.String() != `[["bob",30],["carol",30],["alice",25],["dave",25]]` {
+			t.Errorf("Sorting by more keys does not work properly, got %s.", people)
+		}
+	})
+
+	t.Run("mergeSorted", func(t *testing.T) {
+		a, b := NewList(1, 3, 5, 7), NewList(2, 3, 4, 8, 9)
+		if !a.MergeSorted(b).Equals(NewList(1, 2, 3, 3, 4, 5, 7, 8, 9)) {
+			t.Error("MergeSorted does not work properly.")
+		}
+		if !a.Equals(NewList(1, 3, 5, 7)) || !b.Equals(NewList(2, 3, 4, 8, 9)) {
+			t.Error("MergeSorted should not modify the lists.")
+		}
+		if !a.MergeSorted(NewList[int]()).Equals(a) || !NewList[int]().MergeSorted(b).Equals(b) {
+			t.Error("MergeSorted with an empty list should return a copy.")
+		}
+		if !NewList("a", "c").MergeSorted(NewList("b")).Equals(NewList("a", "b", "c")) {
+			t.Error("MergeSorted of strings does not work properly.")
+		}
+		byTens := func(a, b int) int { return a/10 - b/10 }
+		if !NewList(10, 21, 30).MergeSortedFunc(NewList(11, 20, 35), byTens).Equals(NewList(10, 11, 21, 20, 30, 35)) {
+			t.Error("MergeSortedFunc should keep elements of the receiver first for equal keys.")
+		}
+		random := rand.New(rand.NewSource(3))
+		sorted := NewList[int]()
+		for i := 0; i < 200; i++ {
+			sorted.InsertSorted(random.Intn(50))
+		}
+		if sorted.Count() != 200 || !sorted.Equals(sorted.SortClone()) {
+			t.Error("InsertSorted should keep the list sorted.")
+		}
+		if !NewList[float64]().InsertSorted(2).InsertSorted(1).InsertSorted(3).InsertSorted(2).Equals(NewList(1.0, 2.0, 2.0, 3.0)) {
+			t.Error("InsertSorted does not work properly.")
+		}
+	})
+
+	t.Run("argsort", func(t *testing.T) {
+		prices := NewList(30.0, 10.0, 20.0, 10.0)
+		names := NewList("c", "a", "b", "a2")
+		order := prices.SortIndices()
+		if !order.Equals(NewList(1, 3, 2, 0)) || !prices.Equals(NewList(30.0, 10.0, 20.0, 10.0)) {
+			t.Error("SortIndices does not work properly.")
+		}
+		if !prices.ReorderBy(order).Equals(prices.SortClone()) {
+			t.Error("ReorderBy of SortIndices should be equal to Sort.")
+		}
+		if !names.ReorderBy(order).Equals(NewList("a", "a2", "b", "c")) {
+			t.Error("Parallel list has not been reordered properly.")
+		}
+		for _, l := range []List[string]{NewList("d", "b", "a", "c"), NewList("x"), NewList[string]()} {
+			if !l.ReorderBy(l.SortIndices()).Equals(l.SortClone()) {
+				t.Errorf("ReorderBy of SortIndices of %s should be equal to Sort.", l)
+			}
+		}
+		rotated := names.Reindex(func(i int) int { return (i + 1) % names.Count() })
+		if !rotated.Equals(NewList("a2", "c", "a", "b")) || !names.Equals(NewList("c", "a", "b", "a2")) {
+			t.Error("Reindex does not work properly.")
+		}
+		// 2x3 matrix stored by rows transposed to 3x2
+		matrix := NewList(1, 2, 3, 4, 5, 6)
+		transposed := matrix.Reindex(func(i int) int { return i%3*2 + i/3 })
+		if !transposed.Equals(NewList(1, 4, 2, 5, 3, 6)) {
+			t.Error("Reindex should transpose a matrix.")
+		}
+		if !names.Reindex(func(i int) int { return i }).Equals(names) || !NewList[int]().Reindex(func(i int) int { return i }).Empty() {
+			t.Error("Reindex by identity should return a copy.")
+		}
+		if !NewList(10, 30, 10, 20).Ranks().Equals(NewList(0, 3, 0, 2)) {
+			t.Error("Ranks do not work properly.")
+		}
+		if !NewList("b", "a", "c").Ranks().Equals(NewList(1, 0, 2)) || !NewList[int]().Ranks().Empty() {
+			t.Error("Ranks do not work properly.")
+		}
+	})
+
+}
+
+func TestAnyList(t *testing.T) {
+
+	bytesEqual := func(a, b []byte) bool { return string(a) == string(b) }
+
+	t.Run("basics", func(t *testing.T) {
+		l := NewAnyList([]byte("a"), []byte("b"))
+		l.Add([]byte("d")).Insert(2, []byte("c"))
+		if string(l.Get(2)) != "c" || l.Count() != 4 {
+			t.Error("Adding and inserting does not work properly.")
+		}
+		if string(l.Replace(0, []byte("z")).Delete(1).Pop()) != "d" {
+			t.Error("Replace, Delete or Pop does not work properly.")
+		}
+		if !l.EqualsFunc(NewAnyList([]byte("z"), []byte("c")), bytesEqual) {
+			t.Error("EqualsFunc does not work properly.")
+		}
+		if l.EqualsFunc(NewAnyList([]byte("z")), bytesEqual) {
+			t.Error("Lists of different lengths should not be equal.")
+		}
+		if !l.ContainsFunc(func(x []byte) bool { return string(x) == "c" }) {
+			t.Error("ContainsFunc does not work properly.")
+		}
+		if l.IndexOfFunc(func(x []byte) bool { return string(x) == "c" }) != 1 ||
+			l.IndexOfFunc(func(x []byte) bool { return string(x) == "x" }) != -1 {
+			t.Error("IndexOfFunc does not work properly.")
+		}
+		if !l.Clone().Reverse().EqualsFunc(NewAnyList([]byte("c"), []byte("z")), bytesEqual) {
+			t.Error("Reverse does not work properly.")
+		}
+		if !l.Concat(NewAnyList([]byte("y")), nil).SubList(1, 0).EqualsFunc(NewAnyList([]byte("c"), []byte("y")), bytesEqual) {
+			t.Error("Concat or SubList does not work properly.")
+		}
+		if !l.Clone().Clear().Empty() || l.Empty() {
+			t.Error("Clear does not work properly.")
+		}
+		if len(NewAnyListFrom([][]byte{{1}, {2}}).GoSlice()) != 2 {
+			t.Error("AnyListFrom does not work properly.")
+		}
+	})
+
+	t.Run("functional", func(t *testing.T) {
+		type record struct {
+			name string
+			tags []string
+		}
+		l := NewAnyList(
+			record{"c", []string{"x"}},
+			record{"a", []string{"x", "y"}},
+			record{"b", nil},
+		)
+		names := ""
+		l.Clone().SortFunc(func(a, b record) int {
+			return len(a.tags) - len(b.tags)
+		}).ForEach(func(r record) { names += r.name })
+		if names != "bca" {
+			t.Error("SortFunc or ForEach does not work properly.")
+		}
+		tagged := l.Filter(func(r record) bool { return len(r.tags) > 0 })
+		if tagged.Count() != 2 {
+			t.Error("Filter does not work properly.")
+		}
+		upper := l.Map(func(r record) record { return record{r.name + r.name, r.tags} })
+		if upper.Get(0).name != "cc" || l.Get(0).name != "c" {
+			t.Error("Map does not work properly.")
+		}
+		total := l.Reduce(record{}, func(acc record, r record) record {
+			return record{acc.name + r.name, append(acc.tags, r.tags...)}
+		})
+		if total.name != "cab" || len(total.tags) != 3 {
+			t.Error("Reduce does not work properly.")
+		}
+	})
+
+}
+
+func TestAnyDict(t *testing.T) {
+
+	bytesEqual := func(a, b []byte) bool { return string(a) == string(b) }
+
+	t.Run("basics", func(t *testing.T) {
+		d := NewAnyDict[string, []byte]().
+			Set("first", []byte("1")).
+			Set("second", []byte("2")).
+			Set("third", []byte("3"))
+		if string(d.Get("second")) != "2" || d.Count() != 3 {
+			t.Error("Set or Get does not work properly.")
+		}
+		if !d.KeyExists("first") || d.KeyExists("fourth") {
+			t.Error("KeyExists does not work properly.")
+		}
+		if !d.Keys().Contains("third") || d.Values().Count() != 3 {
+			t.Error("Keys or Values does not work properly.")
+		}
+		if !d.ContainsFunc(func(v []byte) bool { return string(v) == "3" }) {
+			t.Error("ContainsFunc does not work properly.")
+		}
+		if d.KeyOfFunc(func(v []byte) bool { return string(v) == "2" }) != "second" {
+			t.Error("KeyOfFunc does not work properly.")
+		}
+		if !d.EqualsFunc(d.Clone(), bytesEqual) {
+			t.Error("Dict should be equal to its clone.")
+		}
+		if d.EqualsFunc(d.Clone().Unset("first").Set("fourth", []byte("1")), bytesEqual) {
+			t.Error("Dicts with different keys should not be equal.")
+		}
+		doubled := d.Map(func(k string, v []byte) []byte { return append(v, v...) })
+		if string(doubled.Get("first")) != "11" || string(d.Get("first")) != "1" {
+			t.Error("Map does not work properly.")
+		}
+		count := 0
+		d.ForEach(func(k string, v []byte) { count += len(v) })
+		if count != 3 {
+			t.Error("ForEach does not work properly.")
+		}
+		if len(d.GoMap()) != 3 || !d.Clear().Empty() {
+			t.Error("GoMap or Clear does not work properly.")
+		}
+		if NewAnyDictFrom(map[int][]int{1: {1}}).Count() != 1 {
+			t.Error("AnyDictFrom does not work properly.")
+		}
+	})
+
+}
+
+func TestHashedDict(t *testing.T) {
+
+	type point struct {
+		coords []int
+	}
+	eq := func(a, b point) bool {
+		if len(a.coords) != len(b.coords) {
+			return false
+		}
+		for i := range a.coords {
+			if a.coords[i] != b.coords[i] {
+				return false
+			}
+		}
+		return true
+	}
+	// deliberately weak hash, all points of the same dimension collide
+	hash := func(p point) string { return strconv.Itoa(len(p.coords)) }
+
+	t.Run("basics", func(t *testing.T) {
+		d := NewHashedDict[point, string](hash, eq).
+			Set(point{[]int{1, 2}}, "a").
+			Set(point{[]int{2, 1}}, "b").
+			Set(point{[]int{3}}, "c").
+			Set(point{[]int{1, 2}}, "d")
+		if d.Count() != 3 {
+			t.Error("Dict should have 3 fields.")
+		}
+		if d.Get(point{[]int{1, 2}}) != "d" || d.Get(point{[]int{2, 1}}) != "b" {
+			t.Error("Colliding keys should be distinguished.")
+		}
+		if !d.KeyExists(point{[]int{3}}) || d.KeyExists(point{[]int{3, 3}}) {
+			t.Error("KeyExists does not work properly.")
+		}
+		if !d.Keys().ContainsFunc(func(p point) bool { return eq(p, point{[]int{2, 1}}) }) || d.Keys().Count() != 3 {
+			t.Error("Keys should return the original keys.")
+		}
+		if !d.Values().Contains("b") || d.Values().Contains("a") {
+			t.Error("Values does not work properly.")
+		}
+		d.Unset(point{[]int{1, 2}})
+		if d.Count() != 2 || d.KeyExists(point{[]int{1, 2}}) || d.Get(point{[]int{2, 1}}) != "b" {
+			t.Error("Unsetting a colliding key should not affect the others.")
+		}
+		if !d.Clone().Clear().Empty() || d.Empty() {
+			t.Error("Clear does not work properly.")
+		}
+		if NewHashedDict[point, int](hash, eq).Set(point{[]int{1}}, 1).String() != `{{coords:[1]}:1}` {
+			t.Error("Serialization does not work properly.")
+		}
+	})
+
+	t.Run("equality", func(t *testing.T) {
+		d := NewHashedDict[point, int](hash, eq).
+			Set(point{[]int{1, 2}}, 1).
+			Set(point{[]int{2, 1}}, 2)
+		c := d.Clone()
+		if !d.Equals(c) {
+			t.Error("Dict should be equal to its clone.")
+		}
+		c.Set(point{[]int{2, 1}}, 3)
+		if d.Equals(c) || d.Get(point{[]int{2, 1}}) != 2 {
+			t.Error("Clone should be independent of the original.")
+		}
+		other := NewHashedDict[point, int](hash, eq).
+			Set(point{[]int{1, 2}}, 1).
+			Set(point{[]int{3, 3}}, 2)
+		if d.Equals(other) {
+			t.Error("Dicts with different keys should not be equal.")
+		}
+		sum := 0
+		d.ForEach(func(_ point, value int) { sum += value })
+		if sum != 3 {
+			t.Error("ForEach does not work properly.")
+		}
+	})
+
+}
+
+func TestSet(t *testing.T) {
+
+	t.Run("basics", func(t *testing.T) {
+		s := NewSet(1, 2, 2, 3)
+		if s.Count() != 3 || !s.Contains(2) || s.Contains(4) {
+			t.Error("Set should contain each element once.")
+		}
+		s.Add(3, 4).Remove(1)
+		if !s.Equals(NewSet(2, 3, 4)) || s.Equals(NewSet(2, 3, 5)) || s.Equals(NewSet(2, 3)) {
+			t.Error("Add or Remove does not work properly.")
+		}
+		c := s.Clone().Add(5)
+		if s.Contains(5) || !c.Contains(5) {
+			t.Error("Clone should be independent of the original.")
+		}
+		if !c.Clear().Empty() || s.Empty() {
+			t.Error("Clear does not work properly.")
+		}
+		sum := 0
+		s.ForEach(func(value int) { sum += value })
+		if sum != 9 {
+			t.Error("ForEach does not work properly.")
+		}
+		if NewSet("a").String() != `["a"]` || NewSet[int]().String() != "[]" {
+			t.Error("Serialization does not work properly.")
+		}
+	})
+
+	t.Run("conversions", func(t *testing.T) {
+		duplicates := NewList(3, 1, 3, 2, 1, 3)
+		s := ToSet(duplicates)
+		if s.Count() != 3 || !s.Equals(NewSet(1, 2, 3)) {
+			t.Error("ToSet should keep all unique elements once.")
+		}
+		if !s.ToList().SortClone().Equals(NewList(1, 2, 3)) {
+			t.Error("ToList does not work properly.")
+		}
+		unique := NewList("c", "a", "b")
+		if !ToSet(unique).ToList().SortClone().Equals(unique.SortClone()) {
+			t.Error("Round trip of unique elements should produce an equal list.")
+		}
+		if !ToSet(NewList[int]()).Empty() {
+			t.Error("Set of an empty list should be empty.")
+		}
+	})
+
+}
+
+func TestIndexedList(t *testing.T) {
+
+	t.Run("lookups", func(t *testing.T) {
+		source := NewList(3, 1, 4, 1, 5, 9, 2, 6, 5, 3, 5)
+		l := NewIndexedList(source)
+		if l.Count() != 11 || l.Empty() || l.Get(2) != 4 {
+			t.Error("IndexedList should keep all elements.")
+		}
+		if !l.Contains(9) || l.Contains(7) {
+			t.Error("Contains does not work properly.")
+		}
+		source.ForEach(func(value int) {
+			if l.IndexOf(value) != source.IndexOf(value) {
+				t.Error("IndexOf should return the first occurrence.")
+			}
+		})
+		if l.IndexOf(7) != -1 {
+			t.Error("IndexOf of a missing element should be -1.")
+		}
+		if l.CountOf(5) != 3 || l.CountOf(1) != 2 || l.CountOf(7) != 0 {
+			t.Error("CountOf does not work properly.")
+		}
+		if l.String() != source.String() || !l.List().Equals(source) {
+			t.Error("Export does not work properly.")
+		}
+		if NewIndexedList(NewList[int]()).Contains(0) || !NewIndexedList(NewList[int]()).Empty() {
+			t.Error("Empty IndexedList should not contain anything.")
+		}
+	})
+
+	t.Run("snapshot", func(t *testing.T) {
+		source := NewList("a", "b")
+		l := NewIndexedList(source)
+		source.Add("c").Replace(0, "z")
+		if l.Contains("c") || l.Get(0) != "a" || l.IndexOf("z") != -1 {
+			t.Error("IndexedList should not reflect changes of the original list.")
+		}
+		l.GoSlice()[0] = "x"
+		l.List().Add("y")
+		if l.Get(0) != "a" || l.Count() != 2 || l.Contains("y") {
+			t.Error("Exported slices and lists should be copies.")
+		}
+	})
+
+}
+
+func TestLRUCache(t *testing.T) {
+
+	t.Run("eviction", func(t *testing.T) {
+		c := NewLRUCache[string, int](3)
+		c.Set("a", 1).Set("b", 2).Set("c", 3)
+		if c.Count() != 3 || c.Capacity() != 3 || c.String() != `{"c":3,"b":2,"a":1}` {
+			t.Errorf("Set does not work properly, got %s.", c)
+		}
+		c.Set("d", 4)
+		if c.Count() != 3 || c.ContainsKey("a") || !c.Keys().Equals(NewList("d", "c", "b")) {
+			t.Errorf("The least recently used entry should be evicted, got %s.", c)
+		}
+		c.Set("e", 5).Set("f", 6)
+		if !c.Keys().Equals(NewList("f", "e", "d")) || !c.Values().Equals(NewList(6, 5, 4)) {
+			t.Errorf("Entries should be evicted in the order of use, got %s.", c)
+		}
+		if _, ok := c.Get("b"); ok {
+			t.Error("Evicted entry should not be found.")
+		}
+	})
+
+	t.Run("recency", func(t *testing.T) {
+		c := NewLRUCache[int, string](2).Set(1, "a").Set(2, "b")
+		if value, ok := c.Get(1); !ok || value != "a" {
+			t.Error("Get does not work properly.")
+		}
+		c.Set(3, "c")
+		if !c.ContainsKey(1) || c.ContainsKey(2) {
+			t.Errorf("Get should mark the entry as recently used, got %s.", c)
+		}
+		c.Set(1, "x").Set(4, "d")
+		if value, _ := c.Get(1); value != "x" || c.ContainsKey(3) {
+			t.Errorf("Updating an entry should mark it as recently used, got %s.", c)
+		}
+		c.ContainsKey(4)
+		c.Set(5, "e")
+		if c.ContainsKey(4) || !c.Keys().Equals(NewList(5, 1)) {
+			t.Errorf("ContainsKey should not change the recency, got %s.", c)
+		}
+	})
+
+	t.Run("removal", func(t *testing.T) {
+		c := NewLRUCache[string, int](2).Set("a", 1).Set("b", 2)
+		c.Evict("a").Evict("missing").Set("c", 3)
+		if c.Count() != 2 || !c.Keys().Equals(NewList("c", "b")) {
+			t.Errorf("Evict does not work properly, got %s.", c)
+		}
+		if !c.Clear().Empty() || !c.Keys().Empty() || c.String() != "{}" {
+			t.Error("Clear does not work properly.")
+		}
+		c.Set("d", 4)
+		if value, ok := c.Get("d"); !ok || value != 4 || c.Count() != 1 {
+			t.Error("Cleared cache should be usable.")
+		}
+	})
+
+}
+
+func TestBuilders(t *testing.T) {
+
+	t.Run("list", func(t *testing.T) {
+		debug := false
+		b := NewListBuilder[string](4).
+			Add("first").
+			AddIf(debug, "verbose").
+			AddIf(!debug, "quiet", "fast").
+			AddAll(NewList("last"))
+		l := b.Build()
+		if !l.Equals(NewList("first", "quiet", "fast", "last")) {
+			t.Error("List builder does not work properly.")
+		}
+		l2 := b.Add("another").Build()
+		if l.Count() != 4 {
+			t.Error("Using the builder after Build should not change built lists.")
+		}
+		if !l2.Equals(NewList("first", "quiet", "fast", "last", "another")) {
+			t.Error("Builder should keep its content after Build.")
+		}
+		l2.Add("modified")
+		if b.Build().Count() != 5 {
+			t.Error("Modifying a built list should not change the builder.")
+		}
+		if !NewListBuilder[int](0).Build().Empty() {
+			t.Error("Empty builder should build an empty list.")
+		}
+	})
+
+	t.Run("dict", func(t *testing.T) {
+		production := true
+		b := NewDictBuilder[string, int](4).
+			Set("port", 80).
+			SetIf(production, "workers", 8).
+			SetIf(!production, "debug", 1).
+			SetAll(map[string]int{"port": 443, "timeout": 30})
+		d := b.Build()
+		if !d.Equals(NewDictFrom(map[string]int{"port": 443, "workers": 8, "timeout": 30})) {
+			t.Error("Dict builder does not work properly.")
+		}
+		d2 := b.Set("retries", 3).Build()
+		if d.Count() != 3 || d2.Count() != 4 {
+			t.Error("Build should create independent snapshots.")
+		}
+		d2.Set("modified", 1)
+		if b.Build().KeyExists("modified") {
+			t.Error("Modifying a built dict should not change the builder.")
+		}
+	})
+
+}
+
+func TestValidation(t *testing.T) {
+
+	errInvalid := errors.New("invalid")
+
+	rejected := func(operation func()) (err error) {
+		defer func() {
+			err, _ = recover().(error)
+		}()
+		operation()
+		return nil
+	}
+
+	t.Run("dict", func(t *testing.T) {
+		d := NewValidatedDict(func(key string, value int) error {
+			if strings.ToLower(key) != key || value < 0 {
+				return errInvalid
+			}
+			return nil
+		})
+		d.Set("a", 1).SetAll(map[string]int{"b": 2}).SetPairs(NewPair("c", 3))
+		if d.Count() != 3 || d.SetE("d", 4) != nil {
+			t.Error("Valid fields should be set.")
+		}
+		invalid := []func(){
+			func() { d.Set("A", 1) },
+			func() { d.Set("e", -1) },
+			func() { d.SetAll(map[string]int{"e": -1}) },
+			func() { d.SetPairs(NewPair("E", 5)) },
+			func() { d.Fill(-1) },
+			func() { d.MapInPlace(func(_ string, value int) int { return -value }) },
+			func() { d.MapValuesInPlace(func(value int) int { return -value }) },
+			func() { d.Clone().Set("f", -1) },
+			func() { d.Map(func(_ string, value int) int { return -value }) },
+			func() { d.MapParallel(func(_ string, value int) int { return -value }, 2) },
+			func() { d.Merge(NewDict[string, int]().Set("X", 0)) },
+		}
+		for i, operation := range invalid {
+			if err := rejected(operation); !errors.Is(err, errInvalid) {
+				t.Errorf("Operation %d should be rejected by the validator, recovered %v.", i, err)
+			}
+		}
+		if !errors.Is(d.SetE("e", -1), errInvalid) || d.KeyExists("e") || d.KeyExists("A") {
+			t.Error("Rejected fields should not be set.")
+		}
+		if d.Fill(7).Get("a") != 7 || d.Pluck("a").Count() != 1 || d.CloneExcept("a").Count() != 3 {
+			t.Error("Valid operations should not be rejected.")
+		}
+		if NewDict[string, int]().SetE("A", -1) != nil {
+			t.Error("Ordinary dicts should not be validated.")
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		l := NewValidatedList(func(value int) error {
+			if value < 0 {
+				return errInvalid
+			}
+			return nil
+		})
+		l.Add(1, 2).AddSlice([]int{3}).AddList(NewList(4)).PrependList(NewList(0)).Insert(1, 5).Replace(0, 6)
+		if !l.Equals(NewList(6, 5, 1, 2, 3, 4)) || l.AddE(7) != nil {
+			t.Error("Valid elements should be added.")
+		}
+		invalid := []func(){
+			func() { l.Add(8, -1) },
+			func() { l.AddSlice([]int{-1}) },
+			func() { l.AddList(NewList(-1)) },
+			func() { l.PrependList(NewList(-1)) },
+			func() { l.Insert(0, -1) },
+			func() { l.Insert(l.Count(), -1) },
+			func() { l.Replace(0, -1) },
+			func() { l.ReplaceAll(6, -1) },
+			func() { l.ReplaceWhere(func(value int) bool { return value > 5 }, -1) },
+			func() { l.Fill(-1) },
+			func() { l.PadLeft(10, -1) },
+			func() { l.PadRight(10, -1) },
+			func() { l.Resize(10, -1) },
+			func() { l.Clone().Add(-1) },
+			func() { l.DeepClone().Add(-1) },
+			func() { l.SortInPlace().InsertSorted(-1) },
+		}
+		for i, operation := range invalid {
+			if err := rejected(operation); !errors.Is(err, errInvalid) {
+				t.Errorf("Operation %d should be rejected by the validator, recovered %v.", i, err)
+			}
+		}
+		if !errors.Is(l.AddE(9, -1), errInvalid) || !l.Equals(NewList(1, 2, 3, 4, 5, 6, 7)) {
+			t.Errorf("Rejected elements should not be stored, got %s.", l)
+		}
+		if err := rejected(func() { l.MapInPlace(func(value int) int { return 4 - value }) }); !errors.Is(err, errInvalid) || !l.Equals(NewList(3, 2, 1, 0, 5, 6, 7)) {
+			t.Errorf("MapInPlace should stop at the first invalid element, got %s.", l)
+		}
+		if l.Resize(2, -1).Count() != 2 || l.ReplaceAll(9, -1).Count() != 2 || !l.Fill(1).PadRight(4, 0).Equals(NewList(1, 1, 0, 0)) {
+			t.Error("Operations storing no invalid element should not be rejected.")
+		}
+		if NewList[int]().AddE(-1) != nil {
+			t.Error("Ordinary lists should not be validated.")
+		}
+	})
+
+}
+
+func TestSQL(t *testing.T) {
+
+	t.Run("dict", func(t *testing.T) {
+		d := NewDictDeterministic[string, any]().Set("name", "x").Set("count", 2).Set("tags", NewList[any]("a", 1.5))
+		value, err := SQLDict{d}.Value()
+		if err != nil || string(value.([]byte)) != `{"name":"x","count":2,"tags":["a",1.5]}` {
+			t.Errorf("Encoding a dict does not work properly, got %v.", value)
+		}
+		var scanned SQLDict
+		if err := scanned.Scan(value); err != nil || scanned.StringOpts(SerializeOptions{SortKeys: true}) != d.StringOpts(SerializeOptions{SortKeys: true}) {
+			t.Errorf("Round trip of a dict does not work properly, got %v.", scanned.Dict)
+		}
+		if err := scanned.Scan(`{"nested":{"a":[1,{"b":null}]}}`); err != nil {
+			t.Fatal(err)
+		}
+		if b, ok := GetPath[any](scanned.Dict, "nested", "a"); !ok || b.(List[any]).Get(1).(Dict[string, any]).String() != `{"b":null}` {
+			t.Error("Nested values should be scanned as collections.")
+		}
+		if err := scanned.Scan(nil); err != nil || scanned.Dict == nil || !scanned.Empty() {
+			t.Error("NULL should be scanned as an empty dict.")
+		}
+		if value, err := (SQLDict{}).Value(); err != nil || value != nil {
+			t.Error("Nil dict should be encoded as NULL.")
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		l := NewList[any](1, "a", true, NewDict[string, any]().Set("x", 0.5))
+		value, err := SQLList{l}.Value()
+		if err != nil || string(value.([]byte)) != `[1,"a",true,{"x":0.5}]` {
+			t.Errorf("Encoding a list does not work properly, got %v.", value)
+		}
+		var scanned SQLList
+		if err := scanned.Scan(value); err != nil || scanned.String() != l.String() {
+			t.Errorf("Round trip of a list does not work properly, got %v.", scanned.List)
+		}
+		if scanned.Get(0) != 1 || scanned.Get(3).(Dict[string, any]).Get("x") != 0.5 {
+			t.Error("Numbers should be scanned as int or float64.")
+		}
+		if err := scanned.Scan(nil); err != nil || scanned.List == nil || !scanned.Empty() {
+			t.Error("NULL should be scanned as an empty list.")
+		}
+		if value, err := (SQLList{}).Value(); err != nil || value != nil {
+			t.Error("Nil list should be encoded as NULL.")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var d SQLDict
+		var l SQLList
+		if d.Scan([]byte(`{"a":`)) == nil || l.Scan(`[1,2`) == nil {
+			t.Error("Malformed JSON should cause an error.")
+		}
+		if d.Scan(`[1]`) == nil || l.Scan(`{"a":1}`) == nil {
+			t.Error("JSON of a different type should cause an error.")
+		}
+		if d.Scan(`{} {}`) == nil || l.Scan(42) == nil {
+			t.Error("Invalid source should cause an error.")
+		}
+		if _, err := (SQLDict{NewDict[string, any]().Set("f", func() {})}).Value(); err == nil {
+			t.Error("Dict not serializable to JSON should cause an error.")
+		}
+	})
+
+}
+
+func TestEncoding(t *testing.T) {
+
+	type yamlMarshaler interface {
+		MarshalYAML() (any, error)
+	}
+	type yamlUnmarshaler interface {
+		UnmarshalYAML(func(any) error) error
+	}
+
+	t.Run("text", func(t *testing.T) {
+		marshaler := NewList(1.5, 2.0).(encoding.TextMarshaler)
+		if text, err := marshaler.MarshalText(); err != nil || string(text) != `[1.5,2]` {
+			t.Errorf("MarshalText of a list does not work properly, got %s.", text)
+		}
+		l := NewList(9.0)
+		if err := l.(encoding.TextUnmarshaler).UnmarshalText([]byte(`[1, 2.5]`)); err != nil || !l.Equals(NewList(1.0, 2.5)) {
+			t.Errorf("UnmarshalText of a list does not work properly, got %s.", l)
+		}
+		if err := l.(encoding.TextUnmarshaler).UnmarshalText([]byte(`["a"]`)); err == nil || !l.Equals(NewList(1.0, 2.5)) {
+			t.Error("Unconvertible elements should cause an error and keep the list unchanged.")
+		}
+		d := NewDictDeterministic[int, string]()
+		if err := d.(encoding.TextUnmarshaler).UnmarshalText([]byte(`{"2":"b","1":"a"}`)); err != nil || d.String() != `{1:"a",2:"b"}` {
+			t.Errorf("UnmarshalText of a dict does not work properly, got %s.", d)
+		}
+		if _, err := d.(encoding.TextMarshaler).MarshalText(); err == nil {
+			t.Error("MarshalText of a dict with non-string keys should cause an error.")
+		}
+		nested := NewDict[string, any]()
+		if err := nested.(encoding.TextUnmarshaler).UnmarshalText([]byte(`{"a":{"b":[1,"x"]}}`)); err != nil {
+			t.Fatal(err)
+		}
+		if text, err := nested.(encoding.TextMarshaler).MarshalText(); err != nil || string(text) != `{"a":{"b":[1,"x"]}}` {
+			t.Errorf("Nested collections should survive the round trip, got %s.", text)
+		}
+		if encoded, err := json.Marshal(map[string]any{"d": nested}); err != nil || string(encoded) != `{"d":{"a":{"b":[1,"x"]}}}` {
+			t.Errorf("Dicts should be encoded by encoding/json as objects, got %s.", encoded)
+		}
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		config := struct {
+			Tags   List[string]
+			Limits Dict[string, int]
+			Extra  Dict[string, any]
+		}{
+			NewList("a", "b"),
+			NewDict[string, int]().Set("cpu", 2),
+			NewDict[string, any]().Set("nested", NewList[any](NewDict[string, any]().Set("x", 1.5))),
+		}
+		tags, _ := config.Tags.(yamlMarshaler).MarshalYAML()
+		limits, _ := config.Limits.(yamlMarshaler).MarshalYAML()
+		extra, _ := config.Extra.(yamlMarshaler).MarshalYAML()
+		document := map[any]any{"tags": tags, "limits": limits, "extra": extra}
+		if encoded, err := json.Marshal(document["extra"]); err != nil || string(encoded) != `{"nested":[{"x":1.5}]}` {
+			t.Errorf("MarshalYAML should produce Go maps and slices, got %s.", encoded)
+		}
+		// simulates a YAML library decoding a mapping into map[any]any as gopkg.in/yaml.v2 does
+		decoder := func(key string) func(any) error {
+			return func(target any) error {
+				*target.(*any) = document[key]
+				return nil
+			}
+		}
+		wrapped := struct {
+			Tags   YAMLList[string]
+			Limits YAMLDict[int, float64]
+		}{}
+		if err := wrapped.Tags.UnmarshalYAML(decoder("tags")); err != nil || !wrapped.Tags.Equals(config.Tags) {
+			t.Error("YAMLList should create the decoded list.")
+		}
+		document["numbers"] = map[string]any{"1": 2}
+		if err := wrapped.Limits.UnmarshalYAML(decoder("numbers")); err != nil || wrapped.Limits.Get(1) != 2.0 {
+			t.Error("YAMLDict should create the decoded dict.")
+		}
+		if value, err := wrapped.Limits.MarshalYAML(); err != nil || value.(map[int]any)[1] != 2.0 {
+			t.Error("YAMLDict should be encoded as a Go map.")
+		}
+		if value, err := (YAMLList[int]{}).MarshalYAML(); err != nil || value != nil {
+			t.Error("Nil YAMLList should be encoded as null.")
+		}
+		restored := struct {
+			Tags   List[string]
+			Limits Dict[string, int]
+			Extra  Dict[string, any]
+		}{NewList[string](), NewDict[string, int](), NewDict[string, any]()}
+		if err := restored.Tags.(yamlUnmarshaler).UnmarshalYAML(decoder("tags")); err != nil || !restored.Tags.Equals(config.Tags) {
+			t.Error("UnmarshalYAML of a list does not work properly.")
+		}
+		if err := restored.Limits.(yamlUnmarshaler).UnmarshalYAML(decoder("limits")); err != nil || !restored.Limits.Equals(config.Limits) {
+			t.Error("UnmarshalYAML of a dict does not work properly.")
+		}
+		if err := restored.Extra.(yamlUnmarshaler).UnmarshalYAML(decoder("extra")); err != nil || restored.Extra.String() != config.Extra.String() {
+			t.Errorf("Nested collections should survive the YAML round trip, got %s.", restored.Extra)
+		}
+		document["weird"] = map[any]any{1: []any{true}}
+		if err := restored.Extra.(yamlUnmarshaler).UnmarshalYAML(decoder("weird")); err != nil || restored.Extra.String() != `{"1":[true]}` {
+			t.Errorf("Non-string keys of YAML mappings should be converted, got %s.", restored.Extra)
+		}
+		if err := restored.Tags.(yamlUnmarshaler).UnmarshalYAML(decoder("limits")); err == nil {
+			t.Error("Decoding a mapping into a list should cause an error.")
+		}
+	})
+
+}
+
+func TestPair(t *testing.T) {
+
+	t.Run("pair", func(t *testing.T) {
+		p := NewPair("first", 1)
+		if p.First() != "first" || p.Second() != 1 {
+			t.Error("Pair accessors do not work properly.")
+		}
+		if first, second := Unpair(p); first != "first" || second != 1 {
+			t.Error("Unpair does not work properly.")
+		}
+		if !p.Equals(NewPair("first", 1)) || p.Equals(NewPair("first", 2)) {
+			t.Error("Pair equality does not work properly.")
+		}
+		if !p.Swap().Equals(NewPair(1, "first")) {
+			t.Error("Swap does not work properly.")
+		}
+		if p.String() != `["first",1]` {
+			t.Error("Pair serialization does not work properly.")
+		}
+		l := NewList(NewPair("a", 1), NewPair("b", 2))
+		if l.String() != `[["a",1],["b",2]]` {
+			t.Error("Serialization of pairs inside a list does not work properly.")
+		}
+		if !l.Contains(NewPair("b", 2)) {
+			t.Error("Pairs should be comparable inside a list.")
+		}
+		if NewDict[string, Pair[int, bool]]().Set("key", NewPair(1, true)).String() != `{"key":[1,true]}` {
+			t.Error("Serialization of pairs inside a dict does not work properly.")
+		}
+	})
+
+	t.Run("triple", func(t *testing.T) {
+		tr := NewTriple("first", 1, true)
+		if tr.First() != "first" || tr.Second() != 1 || !tr.Third() {
+			t.Error("Triple accessors do not work properly.")
+		}
+		if first, second, third := Untriple(tr); first != "first" || second != 1 || !third {
+			t.Error("Untriple does not work properly.")
+		}
+		if !tr.Equals(NewTriple("first", 1, true)) || tr.Equals(NewTriple("first", 1, false)) {
+			t.Error("Triple equality does not work properly.")
+		}
+		if NewList(tr).String() != `[["first",1,true]]` {
+			t.Error("Triple serialization does not work properly.")
+		}
+	})
+
+}
+
 func TestTools(t *testing.T) {
 
+	t.Run("collection", func(t *testing.T) {
+		collections := []Collection{
+			NewList(1, 2),
+			NewDict[string, int]().Set("a", 1),
+			NewSet("x", "y", "z"),
+			NewAnyList([]int{1}),
+			NewAnyDict[string, []int](),
+			NewHashedDict[[]int, int](func(key []int) string { return fmt.Sprint(key) }, slices.Equal[[]int]),
+			NewIndexedList(NewList("a")),
+		}
+		if TotalCount(collections...) != 8 || TotalCount(nil, NewList(1)) != 1 || TotalCount() != 0 {
+			t.Error("TotalCount does not work properly.")
+		}
+		if !collections[4].Empty() || collections[0].String() != `[1,2]` {
+			t.Error("Collection methods do not work properly.")
+		}
+	})
+
 	t.Run("mapList", func(t *testing.T) {
 		l := NewList(1, 2, 3)
 		t1 := NewList("1", "2", "3")
@@ -357,21 +2260,667 @@ func TestTools(t *testing.T) {
 		}
 	})
 
-	t.Run("mapDict", func(t *testing.T) {
-		o := NewDict[string, int]().
-			Set("first", 1).
-			Set("second", 2).
-			Set("third", 3)
-		t1 := NewDict[string, string]().
-			Set("first", "1").
-			Set("second", "2").
-			Set("third", "3")
-		if !MapDict(o, func(key string, value int) string {
-			return strconv.Itoa(value)
-		}).Equals(t1) {
-			t.Error("MapDict does not work properly.")
-		}
-	})
+	t.Run("mapListIndexed", func(t *testing.T) {
+		l := NewList("a", "b", "c")
+		ranked := MapListIndexed(l, func(i int, value string) string {
+			return strconv.Itoa(i+1) + ". " + value
+		})
+		if !ranked.Equals(NewList("1. a", "2. b", "3. c")) {
+			t.Error("MapListIndexed does not work properly.")
+		}
+		offsets := MapListIndexed(NewList(5, 5, 5, 5), func(i int, value int) float64 {
+			return float64(value - i)
+		})
+		if offsets.Count() != 4 || !offsets.Equals(NewList(5.0, 4.0, 3.0, 2.0)) {
+			t.Error("MapListIndexed should keep the length and pass sequential indexes.")
+		}
+		if !MapListIndexed(NewList[int](), func(int, int) int { return 0 }).Empty() {
+			t.Error("MapListIndexed of an empty list should be empty.")
+		}
+	})
+
+	t.Run("filterMapList", func(t *testing.T) {
+		parse := func(word string) (int, bool) {
+			number, err := strconv.Atoi(word)
+			return number, err == nil
+		}
+		if !FilterMapList(NewList("1", "2", "3"), parse).Equals(NewList(1, 2, 3)) {
+			t.Error("FilterMapList should keep all successfully mapped elements.")
+		}
+		if !FilterMapList(NewList("a", "b"), parse).Empty() {
+			t.Error("FilterMapList should drop all unsuccessfully mapped elements.")
+		}
+		if !FilterMapList(NewList("1", "a", "2", "b", "3"), parse).Equals(NewList(1, 2, 3)) {
+			t.Error("FilterMapList does not work properly.")
+		}
+	})
+
+	t.Run("mapListErr", func(t *testing.T) {
+		if result, err := MapListErr(NewList("1", "2", "3"), strconv.Atoi); err != nil || !result.Equals(NewList(1, 2, 3)) {
+			t.Error("MapListErr should map all elements without an error.")
+		}
+		if result, err := MapListErr(NewList("a", "b"), strconv.Atoi); err == nil || !result.Empty() {
+			t.Error("MapListErr should drop all failed elements and return an error.")
+		}
+		result, err := MapListErr(NewList("1", "a", "2", "b"), strconv.Atoi)
+		if !result.Equals(NewList(1, 2)) {
+			t.Error("MapListErr should keep the successfully mapped elements.")
+		}
+		var numErr *strconv.NumError
+		if !errors.As(err, &numErr) || numErr.Num != "a" || !strings.Contains(err.Error(), `"b"`) {
+			t.Errorf("MapListErr should join all the errors, got %v.", err)
+		}
+	})
+
+	t.Run("mapAny", func(t *testing.T) {
+		l := NewList(1, 2, 3)
+		strings := l.
+			Filter(func(value int) bool { return value > 1 }).
+			MapAny(func(value int) any { return strconv.Itoa(value) })
+		if !strings.Equals(NewList[any]("2", "3")) {
+			t.Error("MapAny does not work properly.")
+		}
+		typed, err := CastList[string](strings)
+		if err != nil || !typed.Equals(NewList("2", "3")) {
+			t.Error("CastList does not work properly.")
+		}
+		back, err := CastList[int](typed.MapAny(func(value string) any {
+			number, _ := strconv.Atoi(value)
+			return number
+		}))
+		if err != nil || !back.Equals(NewList(2, 3)) {
+			t.Error("Round trip through MapAny and CastList does not work properly.")
+		}
+		if _, err := CastList[int](NewList[any](1, "2", 3)); err == nil || err.Error() != "element 1 of type string cannot be cast to int" {
+			t.Error("CastList should fail on the first mismatching element.")
+		}
+	})
+
+	t.Run("typeNarrowing", func(t *testing.T) {
+		nested := NewList[any](1.5)
+		l := NewList[any](1.0, "two", nil, 3.0, nested, true)
+		if !FilterType[float64](l).Equals(NewList(1.0, 3.0)) {
+			t.Error("FilterType does not work properly.")
+		}
+		if !FilterType[List[any]](l).Equals(NewList[List[any]](nested)) {
+			t.Error("FilterType should work with nested lists.")
+		}
+		if !FilterType[int](l).Empty() {
+			t.Error("FilterType should return an empty list if no element matches.")
+		}
+		strings, rest := PartitionType[string](l)
+		if !strings.Equals(NewList("two")) || !rest.Equals(NewList[any](1.0, nil, 3.0, nested, true)) {
+			t.Error("PartitionType does not work properly.")
+		}
+		if !MustCastList[float64](NewList[any](1.0, 2.0)).Equals(NewList(1.0, 2.0)) {
+			t.Error("MustCastList does not work properly.")
+		}
+	})
+
+	t.Run("interleave", func(t *testing.T) {
+		a, b, c := NewList(1, 4, 6, 7), NewList(2), NewList(3, 5)
+		if !InterleaveLists(a, b, c).Equals(NewList(1, 2, 3, 4, 5, 6, 7)) {
+			t.Error("InterleaveLists of lists of unequal length does not work properly.")
+		}
+		if !InterleaveLists(a).Equals(a) || !InterleaveLists(a, NewList[int](), nil).Equals(a) {
+			t.Error("InterleaveLists of a single list should copy it.")
+		}
+		if !InterleaveLists[int]().Empty() || !InterleaveLists(NewList[int](), NewList[int]()).Empty() {
+			t.Error("InterleaveLists of empty inputs should be empty.")
+		}
+		if a.Count() != 4 || b.Count() != 1 || c.Count() != 2 {
+			t.Error("InterleaveLists should not change the original lists.")
+		}
+	})
+
+	t.Run("bridges", func(t *testing.T) {
+		d := NewDictDeterministic[string, int]().Set("b", 2).Set("a", 1)
+		if !NewListFromDict(d).Equals(NewList(NewPair("b", 2), NewPair("a", 1))) {
+			t.Error("NewListFromDict does not work properly.")
+		}
+		if !NewListFromDict(NewDict[string, int]()).Empty() {
+			t.Error("NewListFromDict of an empty dict should be empty.")
+		}
+		words := NewList("apple", "avocado", "banana")
+		byInitial := NewDictFromList(words, func(word string) byte { return word[0] })
+		if byInitial.Count() != 2 || byInitial.Get('a') != "avocado" || byInitial.Get('b') != "banana" {
+			t.Error("NewDictFromList should keep the last element of a colliding key.")
+		}
+		if !NewDictFromList(NewList[string](), func(word string) int { return len(word) }).Empty() {
+			t.Error("NewDictFromList of an empty list should be empty.")
+		}
+	})
+
+	t.Run("entries", func(t *testing.T) {
+		d := NewDictDeterministic[string, int]().Set("b", 2).Set("c", 3).Set("a", 1)
+		entries := d.Entries()
+		if !entries.Equals(NewList(Entry[string, int]{"b", 2}, Entry[string, int]{"c", 3}, Entry[string, int]{"a", 1})) {
+			t.Error("Entries does not work properly.")
+		}
+		if entries.String() != `[{"key":"b","value":2},{"key":"c","value":3},{"key":"a","value":1}]` {
+			t.Errorf("Entries have not been serialized properly, got %s.", entries)
+		}
+		entries.Replace(0, Entry[string, int]{"x", 0})
+		if d.KeyExists("x") {
+			t.Error("Entries should be a snapshot of the dict.")
+		}
+		u := NewDict[string, int]().Set("first", 1).Set("second", 2)
+		if !NewDictFromEntryList(u.Entries()).Equals(u) || !NewDictFromEntryList(NewList[Entry[string, int]]()).Empty() {
+			t.Error("Round trip through Entries does not work properly.")
+		}
+		byKey := MapList(d.Entries(), func(entry Entry[string, int]) string { return entry.Key }).SortIndices()
+		if d.Entries().ReorderBy(byKey).String() != `[{"key":"a","value":1},{"key":"b","value":2},{"key":"c","value":3}]` {
+			t.Error("Entries should be sortable by key.")
+		}
+		if pairs := d.Pairs(); !pairs.Equals(NewList(NewPair("b", 2), NewPair("c", 3), NewPair("a", 1))) || pairs.String() != `[["b",2],["c",3],["a",1]]` {
+			t.Errorf("Pairs does not work properly, got %s.", pairs)
+		}
+		if !NilDict[string, int]().Pairs().Empty() {
+			t.Error("Pairs of a nil dict should be empty.")
+		}
+		duplicate := NewList(Entry[string, int]{"a", 1}, Entry[string, int]{"a", 2})
+		if NewDictFromEntryList(duplicate).Get("a") != 2 {
+			t.Error("NewDictFromEntryList should keep the last entry of a duplicate key.")
+		}
+	})
+
+	t.Run("listToDict", func(t *testing.T) {
+		type user struct {
+			id   int
+			name string
+		}
+		byID := func(u user) (int, string) { return u.id, u.name }
+		users := NewList(user{1, "alice"}, user{2, "bob"}, user{3, "carol"})
+		if !ListToDict(users, byID).Equals(NewDictFrom(map[int]string{1: "alice", 2: "bob", 3: "carol"})) {
+			t.Error("ListToDict does not work properly.")
+		}
+		users.Add(user{2, "bobby"})
+		if d := ListToDict(users, byID); d.Count() != 3 || d.Get(2) != "bobby" {
+			t.Error("ListToDict should keep the last value of a duplicate key.")
+		}
+		if !ListToDict(NewList[user](), byID).Empty() {
+			t.Error("ListToDict of an empty list should be empty.")
+		}
+	})
+
+	t.Run("window", func(t *testing.T) {
+		l := NewList(0, 1, 2, 3, 4)
+		cases := []struct {
+			size, step int
+			result     string
+		}{
+			{2, 1, "[[0,1],[1,2],[2,3],[3,4]]"},
+			{2, 2, "[[0,1],[2,3]]"},
+			{3, 2, "[[0,1,2],[2,3,4]]"},
+			{1, 3, "[[0],[3]]"},
+			{5, 1, "[[0,1,2,3,4]]"},
+			{6, 1, "[]"},
+		}
+		for _, c := range cases {
+			if result := Window(l, c.size, c.step).String(); result != c.result {
+				t.Errorf("Window(%d, %d) should be %s, got %s.", c.size, c.step, c.result, result)
+			}
+		}
+		windows := Window(l, 2, 1)
+		windows.Get(0).Replace(0, 9)
+		if l.Get(0) != 0 || windows.Get(1).Get(0) != 1 {
+			t.Error("Windows should be independent of the list.")
+		}
+		if !Window(NewList[int](), 1, 1).Empty() {
+			t.Error("Window of an empty list should be empty.")
+		}
+	})
+
+	t.Run("repeat", func(t *testing.T) {
+		l := NewList(1, 2)
+		if !RepeatList(l, 0).Empty() || !RepeatList(NewList[int](), 3).Empty() {
+			t.Error("RepeatList with no repetitions or elements should be empty.")
+		}
+		if once := RepeatList(l, 1); !once.Equals(l) || once == l {
+			t.Error("RepeatList with one repetition should be a copy.")
+		}
+		if !RepeatList(l, 3).Equals(NewList(1, 2, 1, 2, 1, 2)) || !l.Equals(NewList(1, 2)) {
+			t.Error("RepeatList does not work properly.")
+		}
+		cycled := NewList[string]()
+		for value := range NewList("a", "b", "c").Cycle() {
+			if cycled.Count() == 7 {
+				break
+			}
+			cycled.Add(value)
+		}
+		if !cycled.Equals(NewList("a", "b", "c", "a", "b", "c", "a")) {
+			t.Errorf("Cycle should wrap around, got %s.", cycled)
+		}
+		for range NewList[int]().Cycle() {
+			t.Fatal("Cycle of an empty list should yield nothing.")
+		}
+	})
+
+	t.Run("urlValues", func(t *testing.T) {
+		d := NewDict[string, string]().Set("q", "a&b c").Set("empty", "").Set("ключ", "значение")
+		query := DictToQueryString(d)
+		if query != "empty=&q=a%26b+c&%D0%BA%D0%BB%D1%8E%D1%87=%D0%B7%D0%BD%D0%B0%D1%87%D0%B5%D0%BD%D0%B8%D0%B5" {
+			t.Errorf("DictToQueryString does not work properly, got %s.", query)
+		}
+		parsed, err := url.ParseQuery(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		back := NewDictFromURLValues(parsed)
+		if back.Count() != 3 || !back.Get("q").Equals(NewList("a&b c")) || !back.Get("empty").Equals(NewList("")) || !back.Get("ключ").Equals(NewList("значение")) {
+			t.Error("Round trip through a query string does not work properly.")
+		}
+		repeated, _ := url.ParseQuery("tag=a&tag=b&x=1")
+		if values := NewDictFromURLValues(repeated); !values.Get("tag").Equals(NewList("a", "b")) || !values.Get("x").Equals(NewList("1")) {
+			t.Error("Repeated keys should be converted to lists.")
+		}
+		if values := DictToURLValues(d); len(values) != 3 || values.Get("q") != "a&b c" {
+			t.Error("DictToURLValues does not work properly.")
+		}
+	})
+
+	t.Run("chunkBy", func(t *testing.T) {
+		equal := func(a, b int) bool { return a == b }
+		increasing := func(a, b int) bool { return a < b }
+		cases := []struct {
+			list     List[int]
+			function func(int, int) bool
+			result   string
+		}{
+			{NewList(1, 2, 1, 2), equal, "[[1],[2],[1],[2]]"},
+			{NewList(7, 7, 7), equal, "[[7,7,7]]"},
+			{NewList(1, 1, 2, 3, 3), equal, "[[1,1],[2],[3,3]]"},
+			{NewList(1, 2, 3, 4), increasing, "[[1,2,3,4]]"},
+			{NewList(1, 5, 2, 3, 0), increasing, "[[1,5],[2,3],[0]]"},
+			{NewList(4), equal, "[[4]]"},
+			{NewList[int](), equal, "[]"},
+		}
+		for _, c := range cases {
+			if result := ChunkBy(c.list, c.function).String(); result != c.result {
+				t.Errorf("ChunkBy of %s should be %s, got %s.", c.list, c.result, result)
+			}
+		}
+		l := NewList(1, 2, 10, 11, 30)
+		chunks := ChunkBy(l, func(prev, curr int) bool { return curr-prev < 5 })
+		joined := NewList[int]()
+		chunks.ForEach(func(chunk List[int]) { joined.AddList(chunk) })
+		if chunks.Count() != 3 || !joined.Equals(l) {
+			t.Error("Chunks should preserve the order and cover the whole list.")
+		}
+		chunks.Get(0).Replace(0, 9)
+		if l.Get(0) != 1 {
+			t.Error("Chunks should be independent of the list.")
+		}
+	})
+
+	t.Run("enumerate", func(t *testing.T) {
+		l := NewList("d", "a", "c", "b")
+		if result := ListToDictIndex(l).String(); result != `{0:"d",1:"a",2:"c",3:"b"}` {
+			t.Errorf("ListToDictIndex does not work properly, got %s.", result)
+		}
+		pairs := Enumerate(l)
+		byIndex := ListToDictIndex(l)
+		l.Replace(0, "z").Add("e")
+		if result := pairs.String(); result != `[[0,"d"],[1,"a"],[2,"c"],[3,"b"]]` || byIndex.Count() != 4 || byIndex.Get(0) != "d" {
+			t.Errorf("Enumerate and ListToDictIndex should be snapshots, got %s.", result)
+		}
+		sorted := pairs.Filter(func(p Pair[int, string]) bool {
+			return p.Second() != "a"
+		}).SortFunc(func(a, b Pair[int, string]) int {
+			return strings.Compare(a.Second(), b.Second())
+		})
+		if result := sorted.String(); result != `[[3,"b"],[2,"c"],[0,"d"]]` {
+			t.Errorf("Indexes should survive filtering and sorting, got %s.", result)
+		}
+		if !Enumerate(NewList[int]()).Empty() || !ListToDictIndex(NewList[int]()).Empty() {
+			t.Error("Empty list should be enumerated as an empty collection.")
+		}
+	})
+
+	t.Run("runLength", func(t *testing.T) {
+		if result := RunLengthEncode(NewList("a", "a", "b", "c", "c", "c")).String(); result != `[["a",2],["b",1],["c",3]]` {
+			t.Errorf("RunLengthEncode does not work properly, got %s.", result)
+		}
+		if result := RunLengthEncode(NewList(4, 4, 4)).String(); result != `[[4,3]]` {
+			t.Errorf("Single run has not been encoded properly, got %s.", result)
+		}
+		if result := RunLengthEncode(NewList(1, 2, 3)).String(); result != `[[1,1],[2,1],[3,1]]` {
+			t.Errorf("List without runs has not been encoded properly, got %s.", result)
+		}
+		if !RunLengthEncode(NewList[int]()).Empty() || !RunLengthDecode(NewList[Pair[int, int]]()).Empty() {
+			t.Error("Empty list should be encoded and decoded as an empty list.")
+		}
+		if !RunLengthDecode(NewList(NewPair("x", 2), NewPair("y", 1))).Equals(NewList("x", "x", "y")) {
+			t.Error("RunLengthDecode does not work properly.")
+		}
+		random := rand.New(rand.NewSource(42))
+		for i := 0; i < 100; i++ {
+			l := NewList[int]()
+			for j := random.Intn(50); j > 0; j-- {
+				l.Add(random.Intn(3))
+			}
+			if !RunLengthDecode(RunLengthEncode(l)).Equals(l) {
+				t.Fatalf("Round trip of %s does not work properly.", l)
+			}
+		}
+	})
+
+	t.Run("weightedSample", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1))
+		items := NewList("a", "b", "c", "d")
+		weights := NewList(1.0, 2.0, 0.0, 7.0)
+		counts := map[string]int{}
+		draws := 100000
+		for i := 0; i < draws; i++ {
+			counts[WeightedSample(items, weights, random)]++
+		}
+		if counts["c"] != 0 {
+			t.Error("Element of zero weight should never be chosen.")
+		}
+		chiSquare := 0.0
+		weights.ForEachIndexed(func(i int, weight float64) {
+			if weight > 0 {
+				expected := float64(draws) * weight / weights.Sum()
+				diff := float64(counts[items.Get(i)]) - expected
+				chiSquare += diff * diff / expected
+			}
+		})
+		// critical value of the chi-square distribution with 2 degrees of freedom at p = 0.001
+		if chiSquare > 13.8 {
+			t.Errorf("Distribution of samples does not follow the weights, chi-square %f, counts %v.", chiSquare, counts)
+		}
+		if WeightedSample(NewList("only"), NewList(0.5), random) != "only" || WeightedSample(NewList(1), NewList(1.0), nil) != 1 {
+			t.Error("Single element should always be chosen.")
+		}
+		sample := WeightedSampleN(items, weights, 3, false, random)
+		if !ToSet(sample).Equals(NewSet("a", "b", "d")) {
+			t.Errorf("Sample without replacement should contain distinct elements of positive weight, got %s.", sample)
+		}
+		repeated := WeightedSampleN(NewList("x", "y"), NewList(1.0, 0.0), 5, true, random)
+		if !repeated.Equals(NewListOf("x", 5)) || !WeightedSampleN(items, weights, 0, false, random).Empty() {
+			t.Error("Sample with replacement does not work properly.")
+		}
+	})
+
+	t.Run("getPath", func(t *testing.T) {
+		leaf := NewDict[string, any]().Set("port", 443).Set("tags", NewList[any]("a"))
+		root := NewDict[string, any]().
+			Set("name", "server").
+			Set("config", NewDict[string, any]().
+				Set("network", leaf).
+				Set("debug", false))
+		if name, ok := GetPath[string](root, "name"); !ok || name != "server" {
+			t.Error("GetPath with one key does not work properly.")
+		}
+		if debug, ok := GetPath[bool](root, "config", "debug"); !ok || debug {
+			t.Error("GetPath with two keys does not work properly.")
+		}
+		if port, ok := GetPath[int](root, "config", "network", "port"); !ok || port != 443 {
+			t.Error("GetPath with three keys does not work properly.")
+		}
+		if network, ok := GetPath[Dict[string, any]](root, "config", "network"); !ok || network != leaf {
+			t.Error("GetPath should return nested dicts.")
+		}
+		if _, ok := GetPath[int](root, "config", "missing", "port"); ok {
+			t.Error("GetPath should fail on a missing intermediate key.")
+		}
+		if _, ok := GetPath[int](root, "config", "network", "missing"); ok {
+			t.Error("GetPath should fail on a missing leaf key.")
+		}
+		if _, ok := GetPath[int](root, "name", "port"); ok {
+			t.Error("GetPath should fail when a non-dict blocks the path.")
+		}
+		if port, ok := GetPath[string](root, "config", "network", "port"); ok || port != "" {
+			t.Error("GetPath should fail on a value of a different type.")
+		}
+		if _, ok := GetPath[int](nil, "port"); ok {
+			t.Error("GetPath should fail on a nil root.")
+		}
+	})
+
+	t.Run("setPath", func(t *testing.T) {
+		root := NewDict[string, any]().Set("name", "server")
+		if err := SetPath(root, 443, "config", "network", "port"); err != nil {
+			t.Error("SetPath should create missing dicts.")
+		}
+		if port, ok := GetPath[int](root, "config", "network", "port"); !ok || port != 443 {
+			t.Error("SetPath does not work properly.")
+		}
+		if err := SetPath(root, true, "config", "debug"); err != nil {
+			t.Error("SetPath should reuse existing dicts.")
+		}
+		if err := SetPath(root, 8080, "config", "network", "port"); err != nil {
+			t.Error("SetPath should overwrite existing values.")
+		}
+		network, _ := GetPath[Dict[string, any]](root, "config", "network")
+		if port, _ := GetPath[int](root, "config", "network", "port"); port != 8080 || network.Count() != 1 {
+			t.Error("SetPath does not overwrite values properly.")
+		}
+		if debug, _ := GetPath[bool](root, "config", "debug"); !debug {
+			t.Error("SetPath should keep sibling fields.")
+		}
+		if err := SetPath(root, "x", "name"); err != nil || root.Get("name") != "x" {
+			t.Error("SetPath with one key does not work properly.")
+		}
+		if err := SetPath(root, 1, "name", "first"); err == nil || err.Error() != `value of type string under key "name" at depth 0 is not a dictionary` {
+			t.Error("SetPath should fail when a non-dict blocks the path.")
+		}
+		if err := SetPath(root, 1, "config", "network", "port", "number"); err == nil {
+			t.Error("SetPath should fail when a non-dict leaf blocks the path.")
+		}
+		if err := SetPath(root, 1); err == nil {
+			t.Error("SetPath should fail on an empty path.")
+		}
+	})
+
+	t.Run("mapDict", func(t *testing.T) {
+		o := NewDict[string, int]().
+			Set("first", 1).
+			Set("second", 2).
+			Set("third", 3)
+		t1 := NewDict[string, string]().
+			Set("first", "1").
+			Set("second", "2").
+			Set("third", "3")
+		if !MapDict(o, func(key string, value int) string {
+			return strconv.Itoa(value)
+		}).Equals(t1) {
+			t.Error("MapDict does not work properly.")
+		}
+		swapped := MapDictFull(o, func(key string, value int) (int, string) { return value, key })
+		if !swapped.Equals(NewDict[int, string]().Set(1, "first").Set(2, "second").Set(3, "third")) {
+			t.Error("MapDictFull does not work properly.")
+		}
+		ordered := NewDictDeterministic[string, int]().Set("apple", 1).Set("avocado", 2).Set("banana", 3)
+		byInitial := MapDictFull(ordered, func(key string, value int) (byte, int) { return key[0], value * 10 })
+		if byInitial.Count() != 2 || byInitial.Get('a') != 20 || byInitial.Get('b') != 30 {
+			t.Error("MapDictFull should keep the last field of a colliding key.")
+		}
+		if !DictKeysList(ordered).Equals(NewList("apple", "avocado", "banana")) || !DictValuesList(ordered).Equals(NewList(1, 2, 3)) {
+			t.Error("DictKeysList and DictValuesList do not work properly.")
+		}
+	})
+
+	t.Run("zipDicts", func(t *testing.T) {
+		prices := NewDict[string, float64]().Set("apple", 0.5).Set("pear", 0.8).Set("plum", 0.2)
+		quantities := NewDict[string, int]().Set("apple", 4).Set("plum", 10).Set("kiwi", 3)
+		if !ZipDicts(prices, quantities).Equals(NewDict[string, Pair[float64, int]]().Set("apple", NewPair(0.5, 4)).Set("plum", NewPair(0.2, 10))) {
+			t.Error("ZipDicts should pair values of common keys only.")
+		}
+		totals := ZipDictsWith(prices, quantities, func(_ string, price float64, quantity int) float64 {
+			return price * float64(quantity)
+		})
+		if !totals.Equals(NewDict[string, float64]().Set("apple", 2.0).Set("plum", 2.0)) {
+			t.Error("ZipDictsWith does not work properly.")
+		}
+		if !ZipDicts(prices, NewDict[string, int]()).Empty() || !ZipDicts(NilDict[string, int](), quantities).Empty() {
+			t.Error("Zipping with an empty dict should give an empty dict.")
+		}
+	})
+}
+
+func TestErrors(t *testing.T) {
+
+	var uninitList []int
+	var uninitDict map[string]int
+
+	t.Run("list", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			call   func(l List[int]) error
+			target error
+		}{
+			{"get", func(l List[int]) error { _, err := l.GetE(3); return err }, ErrIndexOutOfRange{3, 3}},
+			{"getNegative", func(l List[int]) error { _, err := l.GetE(-1); return err }, ErrIndexOutOfRange{-1, 3}},
+			{"delete", func(l List[int]) error { return l.DeleteE(0, 5) }, ErrIndexOutOfRange{5, 3}},
+			{"pop", func(l List[int]) error { l.Clear(); _, err := l.PopE(); return err }, ErrEmpty},
+			{"subListStart", func(l List[int]) error { _, err := l.SubListE(4, 0); return err }, ErrIndexOutOfRange{4, 3}},
+			{"subListEnd", func(l List[int]) error { _, err := l.SubListE(0, -4); return err }, ErrIndexOutOfRange{-4, 3}},
+			{"subListRange", func(l List[int]) error { _, err := l.SubListE(2, 1); return err }, ErrInvalidRange{2, 1}},
+			{"uninitGet", func(List[int]) error { _, err := NewListFrom(uninitList).GetE(0); return err }, ErrIndexOutOfRange{0, 0}},
+			{"uninitDelete", func(List[int]) error { return NewListFrom(uninitList).DeleteE(0) }, ErrNotInitialized},
+			{"uninitPop", func(List[int]) error { _, err := NewListFrom(uninitList).PopE(); return err }, ErrNotInitialized},
+			{"uninitSubList", func(List[int]) error { _, err := NewListFrom(uninitList).SubListE(0, 0); return err }, ErrNotInitialized},
+		}
+		for _, c := range cases {
+			if err := c.call(NewList(1, 2, 3)); !errors.Is(err, c.target) {
+				t.Errorf("%s should return %v, got %v.", c.name, c.target, err)
+			}
+		}
+		var outOfRange ErrIndexOutOfRange
+		if _, err := NewList[int]().GetE(0); !errors.As(err, &outOfRange) || outOfRange.Index != 0 || outOfRange.Len != 0 {
+			t.Error("GetE error should be assertable to ErrIndexOutOfRange.")
+		}
+		l := NewList(1, 2, 3)
+		if l.DeleteE(0, 3) == nil || l.Count() != 3 {
+			t.Error("DeleteE should not delete anything if some index is invalid.")
+		}
+		if l.DeleteE(2, 0, 2) != nil || !l.Equals(NewList(2)) {
+			t.Error("DeleteE does not work properly.")
+		}
+		if value, err := l.GetE(0); err != nil || value != 2 {
+			t.Error("GetE does not work properly.")
+		}
+		if value, err := l.PopE(); err != nil || value != 2 || !l.Empty() {
+			t.Error("PopE does not work properly.")
+		}
+		if sub, err := NewList(1, 2, 3).SubListE(1, 0); err != nil || !sub.Equals(NewList(2, 3)) {
+			t.Error("SubListE does not work properly.")
+		}
+	})
+
+	t.Run("dict", func(t *testing.T) {
+		cases := []struct {
+			name   string
+			call   func(d Dict[string, int]) error
+			target error
+		}{
+			{"get", func(d Dict[string, int]) error { _, err := d.GetE("c"); return err }, ErrKeyNotFound{"c"}},
+			{"unset", func(d Dict[string, int]) error { return d.UnsetE("a", "c") }, ErrKeyNotFound{"c"}},
+			{"keyOf", func(d Dict[string, int]) error { _, err := d.KeyOfE(3); return err }, ErrValueNotFound{3}},
+			{"uninitGet", func(Dict[string, int]) error { _, err := NewDictFrom(uninitDict).GetE("a"); return err }, ErrKeyNotFound{"a"}},
+			{"uninitUnset", func(Dict[string, int]) error { return NewDictFrom(uninitDict).UnsetE("a") }, ErrNotInitialized},
+			{"uninitKeyOf", func(Dict[string, int]) error { _, err := NewDictFrom(uninitDict).KeyOfE(1); return err }, ErrValueNotFound{1}},
+		}
+		for _, c := range cases {
+			if err := c.call(NewDict[string, int]().Set("a", 1).Set("b", 2)); !errors.Is(err, c.target) {
+				t.Errorf("%s should return %v, got %v.", c.name, c.target, err)
+			}
+		}
+		var notFound ErrKeyNotFound
+		if _, err := NewDict[string, int]().GetE("x"); !errors.As(err, &notFound) || notFound.Key != "x" {
+			t.Error("GetE error should be assertable to ErrKeyNotFound.")
+		}
+		d := NewDictDeterministic[string, int]().Set("a", 1).Set("b", 2).Set("c", 3)
+		if d.UnsetE("a", "x") == nil || d.Count() != 3 {
+			t.Error("UnsetE should not delete anything if some key does not exist.")
+		}
+		if d.UnsetE("a", "a", "c") != nil || d.String() != `{"b":2}` {
+			t.Error("UnsetE does not work properly.")
+		}
+		if value, err := d.GetE("b"); err != nil || value != 2 {
+			t.Error("GetE does not work properly.")
+		}
+		if key, err := d.KeyOfE(2); err != nil || key != "b" {
+			t.Error("KeyOfE does not work properly.")
+		}
+	})
+
+}
+
+func TestNilReads(t *testing.T) {
+
+	var uninitDict map[string]int
+	var uninitList []int
+
+	t.Run("dict", func(t *testing.T) {
+		for name, d := range map[string]Dict[string, int]{"nil": NilDict[string, int](), "uninitialized": NewDictFrom(uninitDict)} {
+			visited := 0
+			d.ForEach(func(string, int) { visited++ }).
+				ForEachKey(func(string) { visited++ }).
+				ForEachValue(func(int) { visited++ })
+			if d.Count() != 0 || !d.Empty() || d.Contains(0) || d.KeyExists("") || visited != 0 {
+				t.Errorf("Reads of a %s dict should behave as of an empty dict.", name)
+			}
+			if d.String() != "{}" || !d.Keys().Empty() || !d.Values().Empty() || len(d.KeysSlice()) != 0 || len(d.ValuesSlice()) != 0 {
+				t.Errorf("Exports of a %s dict should be empty.", name)
+			}
+			if !d.Equals(NewDict[string, int]()) || !NewDict[string, int]().Equals(d) || d.Equals(NewDict[string, int]().Set("a", 1)) || !d.Equals(nil) {
+				t.Errorf("A %s dict should be equal to an empty dict.", name)
+			}
+			if _, err := d.GetE("a"); !errors.Is(err, ErrKeyNotFound{"a"}) {
+				t.Errorf("GetE on a %s dict should return ErrKeyNotFound.", name)
+			}
+			if _, err := d.KeyOfE(1); !errors.Is(err, ErrValueNotFound{1}) {
+				t.Errorf("KeyOfE on a %s dict should return ErrValueNotFound.", name)
+			}
+			if err := d.UnsetE("a"); !errors.Is(err, ErrNotInitialized) {
+				t.Errorf("UnsetE on a %s dict should return ErrNotInitialized.", name)
+			}
+			func() {
+				defer func() {
+					if err, ok := recover().(error); !ok || !errors.Is(err, ErrNotInitialized) {
+						t.Errorf("Set on a %s dict should panic with ErrNotInitialized, recovered %v.", name, err)
+					}
+				}()
+				d.Set("a", 1)
+			}()
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		for name, l := range map[string]List[int]{"nil": NilList[int](), "uninitialized": NewListFrom(uninitList)} {
+			visited := 0
+			l.ForEach(func(int) { visited++ }).
+				ForEachIndexed(func(int, int) { visited++ }).
+				ForEachBatched(1, func(List[int]) { visited++ })
+			if l.Count() != 0 || !l.Empty() || l.Contains(0) || l.IndexOf(0) != -1 || l.CountOf(0) != 0 || visited != 0 {
+				t.Errorf("Reads of a %s list should behave as of an empty list.", name)
+			}
+			if out, err := l.JSONString(); out != "[]" || err != nil {
+				t.Errorf("JSONString of a %s list should be an empty array.", name)
+			}
+			if l.String() != "[]" || !l.Equals(NewList[int]()) || !NewList[int]().Equals(l) || l.Equals(NewList(1)) || !l.Equals(nil) {
+				t.Errorf("A %s list should be equal to an empty list.", name)
+			}
+			if _, err := l.GetE(0); !errors.Is(err, ErrIndexOutOfRange{0, 0}) {
+				t.Errorf("GetE on a %s list should return ErrIndexOutOfRange.", name)
+			}
+			if _, err := l.PopE(); !errors.Is(err, ErrNotInitialized) {
+				t.Errorf("PopE on a %s list should return ErrNotInitialized.", name)
+			}
+			func() {
+				defer func() {
+					if err, ok := recover().(error); !ok || !errors.Is(err, ErrNotInitialized) {
+						t.Errorf("Add on a %s list should panic with ErrNotInitialized, recovered %v.", name, err)
+					}
+				}()
+				l.Add(1)
+			}()
+		}
+	})
+
 }
 
 func TestPanics(t *testing.T) {
@@ -382,76 +2931,671 @@ func TestPanics(t *testing.T) {
 		}
 	}
 
+	expect := func(check func(err error) bool, msg string) {
+		r := recover()
+		if err, ok := r.(error); !ok || !check(err) {
+			t.Errorf("%s, recovered %v", msg, r)
+		}
+	}
+
+	is := func(target error) func(error) bool {
+		return func(err error) bool {
+			return errors.Is(err, target)
+		}
+	}
+
+	outOfRange := func(index, count int) func(error) bool {
+		return func(err error) bool {
+			var e ErrIndexOutOfRange
+			return errors.As(err, &e) && e.Index == index && e.Len == count
+		}
+	}
+
 	t.Run("uninitDict", func(t *testing.T) {
-		defer catch("setting to uninitialized dict did not cause panic")
+		defer expect(func(err error) bool {
+			return errors.Is(err, ErrNotInitialized) && err.Error() == "dictionary is not initialized"
+		}, "setting to uninitialized dict did not cause ErrNotInitialized")
 		var uninit map[string]int
 		NewDictFrom(uninit).Set("first", 1)
 	})
 
 	t.Run("keyCheck", func(t *testing.T) {
-		defer catch("unsetting non-existing key did not cause panic")
+		defer expect(func(err error) bool {
+			var e ErrKeyNotFound
+			return errors.As(err, &e) && e.Key == "test" && err.Error() == `key "test" does not exist`
+		}, "unsetting non-existing key did not cause ErrKeyNotFound")
 		NewDict[string, int]().Unset("test")
 	})
 
 	t.Run("valueCheck", func(t *testing.T) {
-		defer catch("unsetting non-existing value did not cause panic")
+		defer expect(func(err error) bool {
+			var e ErrValueNotFound
+			return errors.As(err, &e) && e.Value == 1 && err.Error() == "value 1 not found"
+		}, "searching for non-existing value did not cause ErrValueNotFound")
 		NewDict[string, int]().KeyOf(1)
 	})
 
 	t.Run("uninitList", func(t *testing.T) {
-		defer catch("setting to uninitialized list did not cause panic")
+		defer expect(func(err error) bool {
+			return errors.Is(err, ErrNotInitialized) && err.Error() == "list is not initialized."
+		}, "setting to uninitialized list did not cause ErrNotInitialized")
 		var uninit []int
 		NewListFrom(uninit).Add(1)
 	})
 
 	t.Run("indexCheck", func(t *testing.T) {
-		defer catch("deleting non-existing element did not cause panic")
+		defer expect(outOfRange(0, 0), "deleting non-existing element did not cause ErrIndexOutOfRange")
 		NewList[int]().Delete(0)
 	})
 
+	t.Run("try", func(t *testing.T) {
+		l := NewList(1, 2)
+		if err := Try(func() { l.Delete(5) }); !outOfRange(5, 2)(err) {
+			t.Errorf("Try did not return ErrIndexOutOfRange, got %v.", err)
+		}
+		if err := Try(func() { NewDict[string, int]().Get("x") }); !errors.Is(err, ErrKeyNotFound{Key: "x"}) {
+			t.Errorf("Try did not return ErrKeyNotFound, got %v.", err)
+		}
+		if err := Try(func() { l.Add(3) }); err != nil || l.Count() != 3 {
+			t.Error("Try of a successful function should return nil.")
+		}
+	})
+
+	t.Run("tryArgument", func(t *testing.T) {
+		if err := Try(func() { Window(NewList(1), 0, 1) }); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Try did not return ErrInvalidArgument, got %v.", err)
+		}
+	})
+
+	t.Run("tryValue", func(t *testing.T) {
+		defer catch("Try did not propagate a panic which is not an error")
+		Try(func() { panic("custom") })
+	})
+
+	t.Run("tryRuntime", func(t *testing.T) {
+		defer catch("Try did not propagate a runtime error")
+		var values []int
+		Try(func() { _ = values[1] })
+	})
+
 	t.Run("emptyPop", func(t *testing.T) {
-		defer catch("poping from empty list did not cause panic")
+		defer expect(func(err error) bool {
+			return errors.Is(err, ErrEmpty) && err.Error() == "cannot pop from an empty list"
+		}, "poping from empty list did not cause ErrEmpty")
 		NewList[int]().Pop()
 	})
 
+	t.Run("emptyFold", func(t *testing.T) {
+		defer expect(is(ErrEmpty), "folding empty list did not cause ErrEmpty")
+		NewList[int]().Fold(func(res, x int) int { return res + x })
+	})
+
+	t.Run("forEachSorted", func(t *testing.T) {
+		defer expect(is(ErrNotSortable), "iterating unsortable keys did not cause ErrNotSortable")
+		NewDict[bool, int]().ForEachSorted(func(bool, int) {})
+	})
+
+	t.Run("sortedValues", func(t *testing.T) {
+		defer expect(is(ErrNotSortable), "sorting unsortable values did not cause ErrNotSortable")
+		NewDict[string, bool]().SortedValues()
+	})
+
+	t.Run("setRemove", func(t *testing.T) {
+		defer expect(func(err error) bool {
+			var e ErrValueNotFound
+			return errors.As(err, &e) && e.Value == 2
+		}, "removing non-existing element did not cause ErrValueNotFound")
+		NewSet(1).Remove(2)
+	})
+
+	t.Run("window", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "non-positive window step did not cause panic")
+		Window(NewList(1, 2), 1, 0)
+	})
+
+	t.Run("resize", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "negative length of resize did not cause panic")
+		NewList(1, 2).Resize(-1, 0)
+	})
+
+	t.Run("runLengthDecode", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "non-positive length of a run did not cause panic")
+		RunLengthDecode(NewList(NewPair(1, 2), NewPair(2, 0)))
+	})
+
+	t.Run("sortIndices", func(t *testing.T) {
+		defer expect(is(ErrNotSortable), "argsorting unsortable list did not cause ErrNotSortable")
+		NewList(true, false).SortIndices()
+	})
+
+	t.Run("reorderLength", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "permutation of a different length did not cause panic")
+		NewList(1, 2, 3).ReorderBy(NewList(0, 1))
+	})
+
+	t.Run("reorderRepeated", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "repeated index in permutation did not cause panic")
+		NewList(1, 2, 3).ReorderBy(NewList(0, 1, 1))
+	})
+
+	t.Run("reorderRange", func(t *testing.T) {
+		defer expect(outOfRange(3, 3), "permutation index out of range did not cause ErrIndexOutOfRange")
+		NewList(1, 2, 3).ReorderBy(NewList(0, 1, 3))
+	})
+
+	t.Run("batchSize", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "non-positive batch size did not cause panic")
+		NewList(1, 2).ForEachBatched(0, func(List[int]) {})
+	})
+
+	t.Run("elementwiseLength", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "element-wise operation on lists of different lengths did not cause panic")
+		NewList(1, 2, 3).AddElementwise(NewList(1, 2))
+	})
+
+	t.Run("scaleType", func(t *testing.T) {
+		defer expect(is(ErrNotNumeric), "scaling non-numeric list did not cause ErrNotNumeric")
+		NewList("a").Scale(2)
+	})
+
+	t.Run("histogramBins", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "non-positive number of bins did not cause panic")
+		NewList(1, 2).Histogram(0)
+	})
+
+	t.Run("histogramEdges", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "non-ascending edges did not cause panic")
+		NewList(1, 2).HistogramEdges(NewList(0.0, 2.0, 2.0))
+	})
+
+	t.Run("histogramEdgesNaN", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "NaN edge did not cause panic")
+		NewList(1, 2).HistogramEdges(NewList(0.0, math.NaN(), 2.0))
+	})
+
+	t.Run("weightsLength", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "weights of a different length did not cause panic")
+		WeightedSample(NewList(1, 2), NewList(1.0), nil)
+	})
+
+	t.Run("weightsNegative", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "negative weight did not cause panic")
+		WeightedSample(NewList(1, 2), NewList(1.0, -1.0), nil)
+	})
+
+	t.Run("weightsNaN", func(t *testing.T) {
+		defer expect(func(err error) bool {
+			return errors.Is(err, ErrInvalidArgument) && err.Error() == "weight at index 1 is not a number"
+		}, "NaN weight did not cause ErrInvalidArgument")
+		WeightedSample(NewList(1, 2), NewList(1.0, math.NaN()), nil)
+	})
+
+	t.Run("weightsZero", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "zero weights did not cause panic")
+		WeightedSample(NewList(1, 2), NewList(0.0, 0.0), nil)
+	})
+
+	t.Run("weightsExhausted", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "drawing more elements than available without replacement did not cause panic")
+		WeightedSampleN(NewList(1, 2, 3), NewList(1.0, 0.0, 1.0), 3, false, nil)
+	})
+
+	t.Run("repeatList", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "negative number of repetitions did not cause panic")
+		RepeatList(NewList(1), -1)
+	})
+
+	t.Run("reindexRepeated", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "mapping to a repeated position did not cause panic")
+		NewList(1, 2, 3).Reindex(func(int) int { return 0 })
+	})
+
+	t.Run("reindexRange", func(t *testing.T) {
+		defer expect(outOfRange(3, 3), "mapping out of range did not cause ErrIndexOutOfRange")
+		NewList(1, 2, 3).Reindex(func(i int) int { return i + 1 })
+	})
+
+	t.Run("splitAt", func(t *testing.T) {
+		defer expect(outOfRange(3, 2), "splitting out of range did not cause ErrIndexOutOfRange")
+		NewList(1, 2).SplitAt(3)
+	})
+
+	t.Run("repeatMethod", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "negative number of copies did not cause panic")
+		NewList(1).Repeat(-1)
+	})
+
+	t.Run("mergeSortedType", func(t *testing.T) {
+		defer expect(is(ErrNotSortable), "merging unsortable lists did not cause ErrNotSortable")
+		NewList(true).MergeSorted(NewList(false))
+	})
+
+	t.Run("insertSortedType", func(t *testing.T) {
+		defer expect(is(ErrNotSortable), "inserting into unsortable list did not cause ErrNotSortable")
+		NewList(true).InsertSorted(false)
+	})
+
 	t.Run("sublist1", func(t *testing.T) {
-		defer catch("sublist ending index out of range did not cause panic")
+		defer expect(outOfRange(1, 0), "sublist ending index out of range did not cause ErrIndexOutOfRange")
 		NewList[int]().SubList(0, 1)
 	})
 
 	t.Run("sublist2", func(t *testing.T) {
-		defer catch("sublist starting index higher than ending index did not cause panic")
+		defer expect(outOfRange(1, 0), "sublist starting index out of range did not cause ErrIndexOutOfRange")
 		NewList[int]().SubList(1, 0)
 	})
 
 	t.Run("sublist3", func(t *testing.T) {
-		defer catch("sublist ending index out of range did not cause panic")
+		defer expect(outOfRange(-1, 0), "sublist negative starting index out of range did not cause ErrIndexOutOfRange")
 		NewList[int]().SubList(-1, 0)
 	})
 
+	t.Run("sublist4", func(t *testing.T) {
+		defer expect(outOfRange(-3, 2), "sublist negative starting index out of range did not cause ErrIndexOutOfRange")
+		NewList(1, 2).SubList(-3, 0)
+	})
+
+	t.Run("sublist5", func(t *testing.T) {
+		defer expect(func(err error) bool {
+			var e ErrInvalidRange
+			return errors.As(err, &e) && e.Start == 2 && e.End == 1
+		}, "sublist negative starting index higher than ending index did not cause ErrInvalidRange")
+		NewList(1, 2, 3).SubList(-1, 1)
+	})
+
+	t.Run("anyListIndex", func(t *testing.T) {
+		defer expect(outOfRange(0, 0), "getting non-existing element did not cause ErrIndexOutOfRange")
+		NewAnyList[[]byte]().Get(0)
+	})
+
+	t.Run("anyDictKeyOf", func(t *testing.T) {
+		defer expect(is(ErrNoMatch), "searching for non-existing value did not cause ErrNoMatch")
+		NewAnyDict[string, []byte]().KeyOfFunc(func(v []byte) bool { return true })
+	})
+
+	t.Run("hashedDictKey", func(t *testing.T) {
+		defer expect(func(err error) bool {
+			var e ErrKeyNotFound
+			return errors.As(err, &e)
+		}, "getting non-existing key did not cause ErrKeyNotFound")
+		NewHashedDict[[]int, int](func(k []int) string { return "" }, func(a, b []int) bool { return len(a) == len(b) }).Get(nil)
+	})
+
+	t.Run("mustCast", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r != "element 1 of type <nil> cannot be cast to float64" {
+				t.Errorf("casting a mismatching element caused unexpected panic: %v", r)
+			}
+		}()
+		MustCastList[float64](NewList[any](1.0, nil))
+	})
+
 	t.Run("sort", func(t *testing.T) {
-		defer catch("sorting unsortable list did not cause panic")
+		defer expect(is(ErrNotSortable), "sorting unsortable list did not cause ErrNotSortable")
 		NewList[bool]().Sort()
 	})
 
 	t.Run("min", func(t *testing.T) {
-		defer catch("getting min of non-numeric list did not cause panic")
+		defer expect(is(ErrNotNumeric), "getting min of non-numeric list did not cause ErrNotNumeric")
 		NewList[string]().Min()
 	})
 
 	t.Run("max", func(t *testing.T) {
-		defer catch("getting max of non-numeric list did not cause panic")
+		defer expect(is(ErrNotNumeric), "getting max of non-numeric list did not cause ErrNotNumeric")
 		NewList[string]().Max()
 	})
 
+	t.Run("sumTyped", func(t *testing.T) {
+		defer expect(is(ErrNotNumeric), "getting typed sum of non-numeric list did not cause ErrNotNumeric")
+		NewList[string]().SumTyped()
+	})
+
+	t.Run("prodTyped", func(t *testing.T) {
+		defer expect(is(ErrNotNumeric), "getting typed prod of non-numeric list did not cause ErrNotNumeric")
+		NewList[string]().ProdTyped()
+	})
+
+	t.Run("uninitSet", func(t *testing.T) {
+		defer expect(func(err error) bool {
+			return errors.Is(err, ErrNotInitialized) && err.Error() == "set is not initialized"
+		}, "adding to nil set did not cause ErrNotInitialized")
+		NilSet[int]().Add(1)
+	})
+
+	t.Run("uninitLRUCache", func(t *testing.T) {
+		defer expect(func(err error) bool {
+			return errors.Is(err, ErrNotInitialized) && err.Error() == "LRU cache is not initialized"
+		}, "setting to nil LRU cache did not cause ErrNotInitialized")
+		NilLRUCache[string, int]().Set("first", 1)
+	})
+
+	t.Run("lruCapacity", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "zero capacity of LRU cache did not cause panic")
+		NewLRUCache[string, int](0)
+	})
+
+	t.Run("sampleEmpty", func(t *testing.T) {
+		defer expect(is(ErrEmpty), "sampling from empty dict did not cause ErrEmpty")
+		NewDict[string, int]().SampleKey(nil)
+	})
+
+	t.Run("sampleSize", func(t *testing.T) {
+		defer expect(is(ErrInvalidArgument), "negative sample size did not cause panic")
+		NewDict[string, int]().SampleN(-1, nil)
+	})
+
+	t.Run("tryMax", func(t *testing.T) {
+		defer expect(is(ErrNotSortable), "getting max of non-ordered list did not cause ErrNotSortable")
+		NewList[bool]().TryMax()
+	})
+
 	t.Run("sum", func(t *testing.T) {
-		defer catch("getting sum of non-numeric list did not cause panic")
+		defer expect(is(ErrNotNumeric), "getting sum of non-numeric list did not cause ErrNotNumeric")
 		NewList[string]().Sum()
 	})
 
 	t.Run("prod", func(t *testing.T) {
-		defer catch("getting prod of non-numeric list did not cause panic")
+		defer expect(is(ErrNotNumeric), "getting prod of non-numeric list did not cause ErrNotNumeric")
 		NewList[string]().Prod()
 	})
 
 }
+
+func BenchmarkAdd(b *testing.B) {
+
+	source := make([]int, 100000)
+	for i := range source {
+		source[i] = i
+	}
+
+	b.Run("loop", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			l := NewList[int]()
+			for _, value := range source {
+				l.Add(value)
+			}
+		}
+	})
+
+	b.Run("slice", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			NewList[int]().AddSlice(source)
+		}
+	})
+
+	b.Run("list", func(b *testing.B) {
+		other := NewListFrom(source)
+		for n := 0; n < b.N; n++ {
+			NewList[int]().AddList(other)
+		}
+	})
+
+}
+
+func BenchmarkSetAll(b *testing.B) {
+
+	source := make(map[int]int, 1000000)
+	pairs := make([]Pair[int, int], 0, 1000000)
+	for i := 0; i < 1000000; i++ {
+		source[i] = i
+		pairs = append(pairs, NewPair(i, i))
+	}
+
+	b.Run("loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			d := NewDict[int, int]()
+			for key, value := range source {
+				d.Set(key, value)
+			}
+		}
+	})
+
+	b.Run("setAll", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			NewDict[int, int]().SetAll(source)
+		}
+	})
+
+	b.Run("setPairs", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			NewDict[int, int]().SetPairs(pairs...)
+		}
+	})
+
+}
+
+func BenchmarkKeys(b *testing.B) {
+
+	d := NewDictWithCapacity[int, int](1000000)
+	for i := 0; i < 1000000; i++ {
+		d.Set(i, i)
+	}
+
+	b.Run("loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			keys := NewList[int]()
+			d.ForEachKey(func(key int) {
+				keys.Add(key)
+			})
+		}
+	})
+
+	b.Run("keys", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			d.Keys()
+		}
+	})
+
+	b.Run("slice", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			d.KeysSlice()
+		}
+	})
+
+}
+
+func BenchmarkContains(b *testing.B) {
+
+	l := NewListWithCapacity[int](500000)
+	for i := 0; i < 500000; i++ {
+		l.Add(i)
+	}
+	indexed := NewIndexedList(l)
+
+	b.Run("list", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			l.Contains(n % 500000)
+		}
+	})
+
+	b.Run("indexed", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			indexed.Contains(n % 500000)
+		}
+	})
+
+}
+
+func BenchmarkSort(b *testing.B) {
+
+	random := rand.New(rand.NewSource(42))
+	ints := make([]int, 1000000)
+	floats := make([]float64, 1000000)
+	strings := make([]string, 1000000)
+	for i := range ints {
+		ints[i] = random.Int()
+		floats[i] = random.Float64()
+		strings[i] = strconv.Itoa(ints[i])
+	}
+
+	b.Run("int", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			l := NewList(ints...)
+			b.StartTimer()
+			l.Sort()
+		}
+	})
+
+	b.Run("float64", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			l := NewList(floats...)
+			b.StartTimer()
+			l.Sort()
+		}
+	})
+
+	b.Run("string", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			b.StopTimer()
+			l := NewList(strings...)
+			b.StartTimer()
+			l.Sort()
+		}
+	})
+
+}
+
+func BenchmarkClone(b *testing.B) {
+
+	l := NewListWithCapacity[int](100000)
+	d := NewDictWithCapacity[int, int](100000)
+	for i := 0; i < 100000; i++ {
+		l.Add(i)
+		d.Set(i, i)
+	}
+
+	b.Run("list", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			l.Clone()
+		}
+	})
+
+	b.Run("dict", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			d.Clone()
+		}
+	})
+
+}
+
+func BenchmarkMap(b *testing.B) {
+
+	l := NewListWithCapacity[int](100000)
+	d := NewDictWithCapacity[int, int](100000)
+	for i := 0; i < 100000; i++ {
+		l.Add(i)
+		d.Set(i, i)
+	}
+
+	b.Run("list", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			l.Map(func(value int) int { return value * 2 })
+		}
+	})
+
+	b.Run("dict", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			d.Map(func(_ int, value int) int { return value * 2 })
+		}
+	})
+
+}
+
+func BenchmarkFilter(b *testing.B) {
+
+	l := NewListWithCapacity[int](100000)
+	for i := 0; i < 100000; i++ {
+		l.Add(i)
+	}
+
+	b.Run("dense", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			l.Filter(func(value int) bool { return value%2 == 0 })
+		}
+	})
+
+	b.Run("sparse", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			l.Filter(func(value int) bool { return value%100 == 0 })
+		}
+	})
+
+}
+
+func BenchmarkMerge(b *testing.B) {
+
+	d := NewDictWithCapacity[int, int](100000)
+	another := NewDictWithCapacity[int, int](100000)
+	for i := 0; i < 100000; i++ {
+		d.Set(i, i)
+		another.Set(i+50000, i)
+	}
+
+	b.Run("merge", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			d.Merge(another)
+		}
+	})
+
+}
+
+func BenchmarkPluck(b *testing.B) {
+
+	d := NewDictWithCapacity[int, int](100000)
+	keys := make([]int, 100000)
+	for i := 0; i < 100000; i++ {
+		d.Set(i, i)
+		keys[i] = i
+	}
+
+	b.Run("pluck", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			d.Pluck(keys...)
+		}
+	})
+
+}
+
+func BenchmarkString(b *testing.B) {
+
+	d := NewDictWithCapacity[string, int](100000)
+	for i := 0; i < 100000; i++ {
+		d.Set(strconv.Itoa(i), i)
+	}
+
+	nested := NewList[any](0)
+	for i := 1; i < 1000; i++ {
+		nested = NewList[any](i, nested, strconv.Itoa(i))
+	}
+
+	b.Run("dict", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_ = d.String()
+		}
+	})
+
+	b.Run("nested", func(b *testing.B) {
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			_ = nested.String()
+		}
+	})
+
+}
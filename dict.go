@@ -4,16 +4,30 @@ Dictionary type
 */
 package collection
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+)
 
 /*
 Dictionary, unordered set of key-value pairs.
+Like a nil Go map, a nil or uninitialized dictionary behaves as an empty one for reading
+(Count, Empty, Contains, KeyExists, GetE, KeyOfE, String, ForEach, Keys, Values, Entries, Pairs and Equals),
+while modifications panic with ErrNotInitialized.
+Invalid arguments (e.g. a negative sample size) cause panics matching ErrInvalidArgument, which Try turns into errors.
 
 Type parameters:
   - K - type of dictionary keys,
   - V - type of dictionary values.
 */
 type Dict[K comparable, V comparable] interface {
+	Collection
 
 	/*
 		Acquires the value of the dictionary.
@@ -49,6 +63,45 @@ type Dict[K comparable, V comparable] interface {
 	*/
 	Set(key K, value V) Dict[K, V]
 
+	/*
+		Sets a value of a field.
+		Unlike Set, returns an error instead of panicking if the field is rejected by the validator of the dictionary.
+
+		Parameters:
+		  - key - key of the field,
+		  - value - value of the field.
+
+		Returns:
+		  - error returned by the validator, ErrNotInitialized if the dictionary is not initialized, nil otherwise.
+	*/
+	SetE(key K, value V) error
+
+	/*
+		Sets all fields of a Go map to the dictionary.
+		Existing keys are overwritten. The map is copied, later changes of it do not affect the dictionary.
+		An empty dictionary is allocated at the final size at once, unless its map is shared with the caller (NewDictFrom, GoMap).
+
+		Parameters:
+		  - goMap - map of fields to be set.
+
+		Returns:
+		  - updated dictionary.
+	*/
+	SetAll(goMap map[K]V) Dict[K, V]
+
+	/*
+		Sets all given key-value pairs to the dictionary in their order.
+		Existing keys are overwritten, if a key occurs more times, the last value is used.
+		An empty dictionary is allocated at the final size at once, unless its map is shared with the caller (NewDictFrom, GoMap).
+
+		Parameters:
+		  - pairs... - any amount of key-value pairs.
+
+		Returns:
+		  - updated dictionary.
+	*/
+	SetPairs(pairs ...Pair[K, V]) Dict[K, V]
+
 	/*
 		Deletes the fields with given keys.
 
@@ -60,6 +113,19 @@ type Dict[K comparable, V comparable] interface {
 	*/
 	Unset(keys ...K) Dict[K, V]
 
+	/*
+		Deletes the fields with given keys.
+		Unlike Unset, returns an error instead of panicking.
+		Nothing is deleted if some of the keys does not exist, repeated keys are deleted once.
+
+		Parameters:
+		  - keys... - any amount of keys to delete.
+
+		Returns:
+		  - ErrKeyNotFound if some of the keys does not exist, ErrNotInitialized if the dictionary is not initialized, nil otherwise.
+	*/
+	UnsetE(keys ...K) error
+
 	/*
 		Deletes all fields in the dictionary.
 
@@ -68,6 +134,17 @@ type Dict[K comparable, V comparable] interface {
 	*/
 	Clear() Dict[K, V]
 
+	/*
+		Overwrites all values of the dictionary with a given value, the keys are kept.
+
+		Parameters:
+		  - value - new value of the fields.
+
+		Returns:
+		  - updated dictionary.
+	*/
+	Fill(value V) Dict[K, V]
+
 	/*
 		Acquires the value under the specified key of the dictionary.
 
@@ -79,6 +156,19 @@ type Dict[K comparable, V comparable] interface {
 	*/
 	Get(key K) V
 
+	/*
+		Acquires the value under the specified key of the dictionary.
+		Unlike Get, returns an error instead of panicking.
+
+		Parameters:
+		  - key - key of the field to get.
+
+		Returns:
+		  - corresponding value (zero value in case of an error),
+		  - ErrKeyNotFound if the key does not exist (a nil dictionary behaves as an empty one), nil otherwise.
+	*/
+	GetE(key K) (V, error)
+
 	/*
 		Serializes the dictionary.
 		If only compatible types are used, the output will be a valid JSON.
@@ -89,6 +179,40 @@ type Dict[K comparable, V comparable] interface {
 	*/
 	String() string
 
+	/*
+		Serializes the dictionary in a human-readable form.
+		Equivalent to StringOpts with spaces after commas and colons.
+
+		Returns:
+		  - string representing the serialized dictionary.
+	*/
+	StringPretty() string
+
+	/*
+		Serializes the dictionary with custom options, which are applied also to nested lists and dictionaries.
+		The output is a valid JSON unless some fields are left out by the MaxElements option.
+
+		Parameters:
+		  - opts - serialization options.
+
+		Returns:
+		  - string representing the serialized dictionary.
+	*/
+	StringOpts(opts SerializeOptions) string
+
+	/*
+		Serializes only a given number of fields of the dictionary, followed by a number of the omitted ones
+		and the total count, e.g. {"a":1,"b":2,… +998 more} (count=1000).
+		Suitable for logging huge dictionaries, the whole dictionary is never serialized.
+
+		Parameters:
+		  - maxElements - maximal number of serialized fields (no limit if not positive).
+
+		Returns:
+		  - string summarizing the dictionary.
+	*/
+	Summary(maxElements int) string
+
 	/*
 		Converts the dictionary into a Go map.
 
@@ -113,6 +237,60 @@ type Dict[K comparable, V comparable] interface {
 	*/
 	Values() List[V]
 
+	/*
+		Converts the dictionary to a list of its entries.
+		The list is a snapshot, modifying it does not affect the dictionary.
+
+		Returns:
+		  - list of key-value entries of the dictionary.
+	*/
+	Entries() List[Entry[K, V]]
+
+	/*
+		Converts the dictionary to a list of key-value pairs.
+		The list is a snapshot, modifying it does not affect the dictionary.
+
+		Returns:
+		  - list of pairs of keys and values of the dictionary.
+	*/
+	Pairs() List[Pair[K, V]]
+
+	/*
+		Convers the dictionary to a slice of its keys.
+		The slice is a copy, modifying it does not affect the dictionary.
+
+		Returns:
+		  - slice of keys of the dictionary.
+	*/
+	KeysSlice() []K
+
+	/*
+		Convers the dictionary to a slice of its values.
+		The slice is a copy, modifying it does not affect the dictionary.
+
+		Returns:
+		  - slice of values of the dictionary.
+	*/
+	ValuesSlice() []V
+
+	/*
+		Convers the dictionary to a sorted list of its keys.
+		Supports the same key types as List.Sort, panics with ErrNotSortable for other types.
+
+		Returns:
+		  - list of keys of the dictionary in ascending order.
+	*/
+	SortedKeys() List[K]
+
+	/*
+		Convers the dictionary to a sorted list of its values.
+		Supports the same value types as List.Sort, panics with ErrNotSortable for other types.
+
+		Returns:
+		  - list of values of the dictionary in ascending order.
+	*/
+	SortedValues() List[V]
+
 	/*
 		Creates a copy of the dictionary.
 
@@ -121,6 +299,15 @@ type Dict[K comparable, V comparable] interface {
 	*/
 	Clone() Dict[K, V]
 
+	/*
+		Creates a deep copy of the dictionary.
+		Nested dictionaries and lists are copied recursively, other values are copied the same way as by Clone.
+
+		Returns:
+		  - copied dictionary.
+	*/
+	DeepClone() Dict[K, V]
+
 	/*
 		Gives a number of fields in the dictionary.
 
@@ -139,7 +326,7 @@ type Dict[K comparable, V comparable] interface {
 
 	/*
 		Checks if the content of the dictionary is equal to the content of another dictionary.
-		Nested dictionaries and lists are compared by reference.
+		Nested dictionaries and lists are compared by reference, a nil dictionary is equal to an empty one.
 
 		Parameters:
 		  - another - a dictionary to compare with.
@@ -150,17 +337,31 @@ type Dict[K comparable, V comparable] interface {
 	Equals(another Dict[K, V]) bool
 
 	/*
-		Creates a new dictionary containing all elements of the old dictionary and another dictionary.
-		The old dictionary remains unchanged.
-		If both dictionaries contain a key, the value from another dictionary is used.
+		Creates a new dictionary containing all elements of the old dictionary and other dictionaries.
+		The dictionaries are applied from left to right, if more of them contain a key, the last value is used.
+		All the dictionaries remain unchanged, nil dictionaries are treated as empty.
+		Without arguments, a copy of the old dictionary is returned.
+
+		Parameters:
+		  - others... - any amount of dictionaries to merge.
+
+		Returns:
+		  - new dictionary.
+	*/
+	Merge(others ...Dict[K, V]) Dict[K, V]
+
+	/*
+		Creates a new dictionary containing the fields whose keys are contained in exactly one of the two dictionaries.
+		Keys contained in both dictionaries are left out, regardless of their values.
+		Both dictionaries remain unchanged, a nil dictionary is treated as empty.
 
 		Parameters:
-		  - another - a dictionary to merge.
+		  - another - a dictionary to compare with.
 
 		Returns:
 		  - new dictionary.
 	*/
-	Merge(another Dict[K, V]) Dict[K, V]
+	SymmetricDifference(another Dict[K, V]) Dict[K, V]
 
 	/*
 		Creates a new dictionary containing the given fields of the existing dictionary.
@@ -173,6 +374,57 @@ type Dict[K comparable, V comparable] interface {
 	*/
 	Pluck(keys ...K) Dict[K, V]
 
+	/*
+		Creates a copy of the dictionary without the given fields.
+		Keys which are not present are skipped.
+
+		Parameters:
+		  - keys... - any amount of keys to be left out.
+
+		Returns:
+		  - created dictionary.
+	*/
+	CloneExcept(keys ...K) Dict[K, V]
+
+	/*
+		Chooses a random key of the dictionary, all keys have the same probability.
+		Panics with ErrEmpty if the dictionary is empty.
+
+		Parameters:
+		  - r - source of randomness (the global one if nil), keys of unordered dictionaries are sorted for it, so seeded draws are reproducible.
+
+		Returns:
+		  - chosen key.
+	*/
+	SampleKey(r *rand.Rand) K
+
+	/*
+		Chooses a random field of the dictionary, all fields have the same probability.
+		Panics with ErrEmpty if the dictionary is empty.
+
+		Parameters:
+		  - r - source of randomness (the global one if nil), keys of unordered dictionaries are sorted for it, so seeded draws are reproducible.
+
+		Returns:
+		  - key of the chosen field,
+		  - value of the chosen field.
+	*/
+	SampleEntry(r *rand.Rand) (K, V)
+
+	/*
+		Creates a new dictionary of n fields chosen randomly without repetition, all subsets have the same probability.
+		If n is not lower than the number of fields, the result is a copy of the dictionary.
+		Panics if n is negative.
+
+		Parameters:
+		  - n - number of fields to choose,
+		  - r - source of randomness (the global one if nil), keys of unordered dictionaries are sorted for it, so seeded draws are reproducible.
+
+		Returns:
+		  - created dictionary.
+	*/
+	SampleN(n int, r *rand.Rand) Dict[K, V]
+
 	/*
 		Checks if the dictionary contains a field with a given value.
 		Nested dictionaries and lists are compared by reference.
@@ -198,6 +450,19 @@ type Dict[K comparable, V comparable] interface {
 	*/
 	KeyOf(value V) K
 
+	/*
+		Gives a key containing the given value.
+		Unlike KeyOf, returns an error instead of panicking.
+
+		Parameters:
+		  - value - the value to check.
+
+		Returns:
+		  - key for the value (zero value in case of an error),
+		  - ErrValueNotFound if no key contains the value (a nil dictionary behaves as an empty one), nil otherwise.
+	*/
+	KeyOfE(value V) (K, error)
+
 	/*
 		Checks if a given key exists within the dictionary.
 
@@ -221,6 +486,54 @@ type Dict[K comparable, V comparable] interface {
 	*/
 	ForEach(function func(k K, v V)) Dict[K, V]
 
+	/*
+		Executes a given function over an every key of the dictionary.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged dictionary.
+	*/
+	ForEachKey(function func(k K)) Dict[K, V]
+
+	/*
+		Executes a given function over an every field of the dictionary in ascending order of keys.
+		Supports the same key types as List.Sort, panics with ErrNotSortable for other types.
+		The function has two parameters: key of the current field and its value.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged dictionary.
+	*/
+	ForEachSorted(function func(k K, v V)) Dict[K, V]
+
+	/*
+		Executes a given function over an every value of the dictionary.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged dictionary.
+	*/
+	ForEachValue(function func(v V)) Dict[K, V]
+
+	/*
+		Executes a given function over an every field of the dictionary for its side effects, e.g. logging.
+		Intended for an inspection of intermediate results in a chain of method calls.
+		The function has two parameters: key of the current field and its value.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged dictionary.
+	*/
+	Tap(function func(k K, v V)) Dict[K, V]
+
 	/*
 		Copies the dictionary and modifies each field by a given mapping function.
 		The resulting field has to be of a same type as the original one.
@@ -234,10 +547,79 @@ type Dict[K comparable, V comparable] interface {
 		  - new dictionary.
 	*/
 	Map(function func(k K, v V) V) Dict[K, V]
+
+	/*
+		Copies the dictionary and modifies each field by a given mapping function, concurrently.
+		The fields are distributed among a given number of goroutines,
+		so the function has to be safe for concurrent use.
+		The function has two parameters: key of the current field and its value.
+		The old dictionary remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed,
+		  - workers - number of goroutines (runtime.NumCPU() if not positive).
+
+		Returns:
+		  - new dictionary.
+	*/
+	MapParallel(function func(k K, v V) V, workers int) Dict[K, V]
+
+	/*
+		Modifies each value of the dictionary by a given mapping function.
+		Unlike Map, the dictionary is modified in place, no new dictionary is allocated.
+		The function has one parameter, the current value.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - updated dictionary.
+	*/
+	MapValuesInPlace(function func(v V) V) Dict[K, V]
+
+	/*
+		Modifies each field of the dictionary by a given mapping function.
+		Unlike Map, the dictionary is modified in place, no new dictionary is allocated.
+		The function has two parameters: key of the current field and its value.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - updated dictionary.
+	*/
+	MapInPlace(function func(k K, v V) V) Dict[K, V]
+
+	/*
+		Passes the dictionary to a given function and returns its result.
+		Allows to insert custom operations into a chain of method calls.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - dictionary returned by the function.
+	*/
+	Pipe(function func(d Dict[K, V]) Dict[K, V]) Dict[K, V]
+
+	/*
+		Passes the whole dictionary to a given function for its side effects, e.g. logging or metrics, and returns the dictionary.
+		Unlike Tap, the function is called once. It receives the dictionary itself, not a copy,
+		so its modifications are visible in the rest of a chain of method calls.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - the same dictionary.
+	*/
+	Inspect(function func(d Dict[K, V])) Dict[K, V]
 }
 
 /*
 Dictionary, a reference type. Contains a map of key-value pairs.
+Optionally records an insertion order of the keys, which is then used for iteration,
+and validates fields stored into it. A map shared with the caller (NewDictFrom, GoMap) is never replaced by bulk inserts.
 
 Implements:
   - Dict.
@@ -247,7 +629,11 @@ Type parameters:
   - V - type of dictionary values.
 */
 type mapDict[K comparable, V comparable] struct {
-	val map[K]V
+	val      map[K]V
+	ordered  bool
+	order    []K
+	validate func(K, V) error
+	shared   bool
 }
 
 /*
@@ -262,16 +648,16 @@ Returns:
   - pointer to the created dictionary.
 */
 func NewDict[K comparable, V comparable]() Dict[K, V] {
-	ego := mapDict[K, V]{make(map[K]V)}
+	ego := mapDict[K, V]{val: make(map[K]V)}
 	return &ego
 }
 
 /*
 Dictionary constructor.
-Converts a map to a dictionary.
+Creates a new dictionary with a preallocated space for a given number of fields.
 
 Parameters:
-  - goMap - original map.
+  - capacity - expected number of fields.
 
 Type parameters:
   - K - type of dictionary keys,
@@ -280,97 +666,652 @@ Type parameters:
 Returns:
   - pointer to the created dictionary.
 */
-func NewDictFrom[K comparable, V comparable](goMap map[K]V) Dict[K, V] {
-	return &mapDict[K, V]{goMap}
+func NewDictWithCapacity[K comparable, V comparable](capacity int) Dict[K, V] {
+	ego := mapDict[K, V]{val: make(map[K]V, capacity)}
+	return &ego
 }
 
-func (ego *mapDict[K, V]) getVal() map[K]V {
-	return ego.val
-}
+/*
+Dictionary constructor.
+Creates a new deterministic dictionary, which records the insertion order of the keys.
+ForEach, Keys, Values, String and other iterating methods follow this order.
+Copies made by Clone, Map, Merge and Pluck are deterministic as well.
+Unsetting a key takes linear time.
 
-func (ego *mapDict[K, V]) assert() {
-	if ego == nil || ego.getVal() == nil {
-		panic("dictionary is not initialized")
-	}
-}
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
 
-func (ego *mapDict[K, V]) checkKey(key K) {
-	if !ego.KeyExists(key) {
-		panic(fmt.Sprintf("key %s does not exist", toString(key)))
-	}
+Returns:
+  - pointer to the created dictionary.
+*/
+func NewDictDeterministic[K comparable, V comparable]() Dict[K, V] {
+	ego := mapDict[K, V]{val: make(map[K]V), ordered: true, order: make([]K, 0)}
+	return &ego
+}
+
+/*
+Dictionary constructor.
+Creates a new validated dictionary, which checks every field stored into it by a given function.
+Set, SetAll, SetPairs, Fill, MapInPlace and MapValuesInPlace panic with the error returned by the validator,
+bulk operations stop at the first rejected field. SetE returns the error instead.
+Copies made by Clone, Map, Merge and Pluck are validated as well.
+
+Parameters:
+  - validate - function returning an error for an invalid field, nil otherwise.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+
+Returns:
+  - pointer to the created dictionary.
+*/
+func NewValidatedDict[K comparable, V comparable](validate func(K, V) error) Dict[K, V] {
+	ego := mapDict[K, V]{val: make(map[K]V), validate: validate}
+	return &ego
+}
+
+/*
+Dictionary constructor.
+Converts a map to a dictionary.
+
+Parameters:
+  - goMap - original map.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+
+Returns:
+  - pointer to the created dictionary.
+*/
+func NewDictFrom[K comparable, V comparable](goMap map[K]V) Dict[K, V] {
+	return &mapDict[K, V]{val: goMap, shared: true}
+}
+
+/*
+Dictionary constructor.
+Creates a dictionary from a list of entries, e.g. produced by Entries.
+If more entries have the same key, the last one is used.
+
+Parameters:
+  - entries - list of key-value entries.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+
+Returns:
+  - pointer to the created dictionary.
+*/
+func NewDictFromEntryList[K comparable, V comparable](entries List[Entry[K, V]]) Dict[K, V] {
+	return ListToDict(entries, func(entry Entry[K, V]) (K, V) {
+		return entry.Key, entry.Value
+	})
+}
+
+/*
+Dictionary constructor.
+Indexes elements of a list by keys derived from them.
+If more elements produce the same key, the last one is used.
+
+Parameters:
+  - list - list to index,
+  - key - function deriving a key from an element.
+
+Type parameters:
+  - T - type of list elements (dictionary values),
+  - K - type of dictionary keys.
+
+Returns:
+  - pointer to the created dictionary.
+*/
+func NewDictFromList[T comparable, K comparable](list List[T], key func(T) K) Dict[K, T] {
+	return ListToDict(list, func(value T) (K, T) {
+		return key(value), value
+	})
+}
+
+func (ego *mapDict[K, V]) serialize(b *strings.Builder) {
+	b.Grow(8 * len(ego.getVal()))
+	b.WriteByte('{')
+	first := true
+	ego.each(func(key K, value V) {
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		writeString(b, key)
+		b.WriteByte(':')
+		writeString(b, value)
+	})
+	b.WriteByte('}')
+}
+
+func (ego *mapDict[K, V]) serializeOptions(b *strings.Builder, opts SerializeOptions, depth int) {
+	keys := ego.KeysSlice()
+	if opts.SortKeys {
+		sortKeys(keys)
+	}
+	b.WriteByte('{')
+	writeElements(b, opts, depth, len(keys), func(i int) {
+		writeString(b, keys[i])
+		opts.writeColon(b)
+		writeStringOptions(b, ego.val[keys[i]], opts, depth+1)
+	})
+	b.WriteByte('}')
+}
+
+/*
+Sorts keys of a dictionary in ascending order.
+Keys of types string, int and float64 are sorted naturally, other keys by their serialized form.
+
+Parameters:
+  - keys - keys to sort.
+
+Type parameters:
+  - K - type of the keys.
+*/
+func sortKeys[K comparable](keys []K) {
+	switch val := any(keys).(type) {
+	case []string:
+		slices.Sort(val)
+	case []int:
+		slices.Sort(val)
+	case []float64:
+		slices.Sort(val)
+	default:
+		slices.SortStableFunc(keys, func(a, b K) int {
+			return strings.Compare(toString(a), toString(b))
+		})
+	}
+}
+
+func (ego *mapDict[K, V]) getVal() map[K]V {
+	if ego == nil {
+		return nil
+	}
+	return ego.val
+}
+
+/*
+Creates a new empty dictionary of the same kind.
+
+Parameters:
+  - capacity - expected number of fields.
+
+Returns:
+  - pointer to the created dictionary.
+*/
+func (ego *mapDict[K, V]) empty(capacity int) *mapDict[K, V] {
+	if ego.ordered {
+		return &mapDict[K, V]{val: make(map[K]V, capacity), ordered: true, order: make([]K, 0, capacity), validate: ego.validate}
+	}
+	return &mapDict[K, V]{val: make(map[K]V, capacity), validate: ego.validate}
+}
+
+/*
+Checks a field by the validator of the dictionary.
+
+Parameters:
+  - key - key of the field,
+  - value - value of the field.
+
+Returns:
+  - error returned by the validator, nil if the field is valid or the dictionary is not validated.
+*/
+func (ego *mapDict[K, V]) check(key K, value V) error {
+	if ego.validate == nil {
+		return nil
+	}
+	return ego.validate(key, value)
+}
+
+/*
+Executes a given function over an every field of the dictionary.
+If the dictionary is deterministic, the fields are visited in the insertion order.
+
+Parameters:
+  - function - function to be executed.
+*/
+func (ego *mapDict[K, V]) each(function func(K, V)) {
+	if ego != nil && ego.ordered {
+		for _, key := range ego.order {
+			function(key, ego.getVal()[key])
+		}
+		return
+	}
+	for key, value := range ego.getVal() {
+		function(key, value)
+	}
+}
+
+/*
+Checks whether the dictionary is initialized.
+
+Returns:
+  - ErrNotInitialized if the dictionary is not initialized, nil otherwise.
+*/
+func (ego *mapDict[K, V]) checkInit() error {
+	if ego == nil || ego.getVal() == nil {
+		return errDictNotInitialized
+	}
+	return nil
+}
+
+func (ego *mapDict[K, V]) assert() {
+	if err := ego.checkInit(); err != nil {
+		panic(err)
+	}
+}
+
+func (ego *mapDict[K, V]) checkKey(key K) {
+	if !ego.KeyExists(key) {
+		panic(ErrKeyNotFound{key})
+	}
 }
 
 func (ego *mapDict[K, V]) Set(key K, value V) Dict[K, V] {
-	ego.assert()
+	if err := ego.SetE(key, value); err != nil {
+		panic(err)
+	}
+	return ego
+}
+
+func (ego *mapDict[K, V]) SetE(key K, value V) error {
+	if err := ego.checkInit(); err != nil {
+		return err
+	}
+	if err := ego.check(key, value); err != nil {
+		return err
+	}
+	if ego.ordered {
+		if _, ok := ego.getVal()[key]; !ok {
+			ego.order = append(ego.order, key)
+		}
+	}
 	ego.getVal()[key] = value
+	return nil
+}
+
+func (ego *mapDict[K, V]) SetAll(goMap map[K]V) Dict[K, V] {
+	ego.assert()
+	ego.grow(len(goMap))
+	for key, value := range goMap {
+		ego.Set(key, value)
+	}
 	return ego
 }
 
-func (ego *mapDict[K, V]) Unset(keys ...K) Dict[K, V] {
+func (ego *mapDict[K, V]) SetPairs(pairs ...Pair[K, V]) Dict[K, V] {
 	ego.assert()
+	ego.grow(len(pairs))
+	for _, pair := range pairs {
+		ego.Set(pair.first, pair.second)
+	}
+	return ego
+}
+
+/*
+Prepares the dictionary for a given number of new fields.
+An empty map owned by the dictionary is replaced by a map of the given capacity,
+a non-empty or shared map cannot be grown in advance.
+
+Parameters:
+  - count - expected number of new fields.
+*/
+func (ego *mapDict[K, V]) grow(count int) {
+	if len(ego.val) == 0 && !ego.shared {
+		ego.val = make(map[K]V, count)
+	}
+	if ego.ordered {
+		ego.order = slices.Grow(ego.order, count)
+	}
+}
+
+func (ego *mapDict[K, V]) Unset(keys ...K) Dict[K, V] {
+	if err := ego.UnsetE(keys...); err != nil {
+		panic(err)
+	}
+	return ego
+}
+
+func (ego *mapDict[K, V]) UnsetE(keys ...K) error {
+	if err := ego.checkInit(); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, ok := ego.getVal()[key]; !ok {
+			return ErrKeyNotFound{key}
+		}
+	}
 	for _, key := range keys {
-		ego.checkKey(key)
+		if _, ok := ego.getVal()[key]; !ok {
+			continue
+		}
 		delete(ego.getVal(), key)
+		if ego.ordered {
+			for i, item := range ego.order {
+				if item == key {
+					ego.order = append(ego.order[:i], ego.order[i+1:]...)
+					break
+				}
+			}
+		}
 	}
-	return ego
+	return nil
 }
 
 func (ego *mapDict[K, V]) Clear() Dict[K, V] {
 	ego.assert()
-	ego.val = make(map[K]V, 0)
+	ego.val, ego.shared = make(map[K]V, 0), false
+	if ego.ordered {
+		ego.order = make([]K, 0)
+	}
 	return ego
 }
 
-func (ego *mapDict[K, V]) Get(key K) V {
+func (ego *mapDict[K, V]) Fill(value V) Dict[K, V] {
 	ego.assert()
-	ego.checkKey(key)
-	return ego.getVal()[key]
+	for key := range ego.val {
+		if err := ego.check(key, value); err != nil {
+			panic(err)
+		}
+	}
+	for key := range ego.val {
+		ego.val[key] = value
+	}
+	return ego
+}
+
+func (ego *mapDict[K, V]) Get(key K) V {
+	value, err := ego.GetE(key)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+func (ego *mapDict[K, V]) GetE(key K) (V, error) {
+	value, ok := ego.getVal()[key]
+	if !ok {
+		return value, ErrKeyNotFound{key}
+	}
+	return value, nil
+}
+
+func (ego *mapDict[K, V]) StringPretty() string {
+	return ego.StringOpts(SerializeOptions{Spaced: true})
+}
+
+func (ego *mapDict[K, V]) StringOpts(opts SerializeOptions) string {
+	var b strings.Builder
+	ego.serializeOptions(&b, opts, 0)
+	return b.String()
+}
+
+func (ego *mapDict[K, V]) Summary(maxElements int) string {
+	count := ego.Count()
+	shown := count
+	if maxElements > 0 && maxElements < count {
+		shown = maxElements
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	write := func(i int, key K) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeString(&b, key)
+		b.WriteByte(':')
+		writeString(&b, ego.val[key])
+	}
+	if ego != nil && ego.ordered {
+		for i, key := range ego.order[:shown] {
+			write(i, key)
+		}
+	} else {
+		i := 0
+		for key := range ego.getVal() {
+			if i == shown {
+				break
+			}
+			write(i, key)
+			i++
+		}
+	}
+	writeSummaryEnd(&b, '}', shown, count)
+	return b.String()
+}
+
+/*
+Formats the dictionary for the fmt package.
+Verbs %v and %s produce the output of String, %+v an indented form and %q a quoted String.
+Precision limits the number of written fields (as Summary does), width pads the output with spaces.
+
+Parameters:
+  - f - formatter state,
+  - verb - formatting verb.
+*/
+func (ego *mapDict[K, V]) Format(f fmt.State, verb rune) {
+	formatCollection(ego, f, verb)
 }
 
 func (ego *mapDict[K, V]) String() string {
-	result := "{"
-	i := 0
-	for key, value := range ego.getVal() {
-		result += toString(key) + ":" + toString(value)
-		if i++; i < len(ego.getVal()) {
-			result += ","
+	var b strings.Builder
+	ego.serialize(&b)
+	return b.String()
+}
+
+/*
+Encodes the dictionary as a JSON object, so it can be nested in values passed to encoding/json.
+
+Returns:
+  - JSON object,
+  - error if the dictionary is not serializable to JSON, nil otherwise.
+*/
+func (ego *mapDict[K, V]) MarshalJSON() ([]byte, error) {
+	encoded := []byte(ego.String())
+	if !json.Valid(encoded) {
+		return nil, fmt.Errorf("dictionary of type %T is not serializable to JSON", ego)
+	}
+	return encoded, nil
+}
+
+/*
+Encodes the dictionary as a JSON object, implements encoding.TextMarshaler.
+
+Returns:
+  - JSON object,
+  - error if the dictionary is not serializable to JSON, nil otherwise.
+*/
+func (ego *mapDict[K, V]) MarshalText() ([]byte, error) {
+	return ego.MarshalJSON()
+}
+
+/*
+Replaces the fields of the dictionary by a decoded JSON object, implements encoding.TextUnmarshaler.
+Nested objects and arrays are decoded as collections, see SQLDict.
+Fields of a deterministic dictionary are ordered by keys.
+
+Parameters:
+  - text - JSON object.
+
+Returns:
+  - error if the text is not a JSON object with keys and values convertible to the types of the dictionary, nil otherwise.
+*/
+func (ego *mapDict[K, V]) UnmarshalText(text []byte) error {
+	value, err := parseJSON(text)
+	if err != nil {
+		return err
+	}
+	return ego.decode(value)
+}
+
+/*
+Converts the dictionary into a Go map for a YAML library, nested collections become Go maps and slices.
+The signature is compatible with gopkg.in/yaml.v2 and gopkg.in/yaml.v3 without depending on them.
+
+Returns:
+  - map of fields,
+  - always nil.
+*/
+func (ego *mapDict[K, V]) MarshalYAML() (any, error) {
+	return ego.goValue(), nil
+}
+
+/*
+Replaces the fields of the dictionary by a decoded YAML mapping.
+The signature is compatible with gopkg.in/yaml.v2 and gopkg.in/yaml.v3 without depending on them.
+Fields of a deterministic dictionary are ordered by keys.
+
+Parameters:
+  - unmarshal - function decoding the YAML node into a given value, provided by the YAML library.
+
+Returns:
+  - error if the node is not a mapping with keys and values convertible to the types of the dictionary, nil otherwise.
+*/
+func (ego *mapDict[K, V]) UnmarshalYAML(unmarshal func(any) error) error {
+	var value any
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+	return ego.decode(fromNative(value))
+}
+
+/*
+Replaces the fields of the dictionary by fields of a decoded dictionary.
+The dictionary is left unchanged if some field cannot be converted.
+
+Parameters:
+  - value - dictionary produced by fromNative.
+
+Returns:
+  - error if the value is not a dictionary or some field cannot be converted, nil otherwise.
+*/
+func (ego *mapDict[K, V]) decode(value any) error {
+	if ego == nil {
+		return errDictNotInitialized
+	}
+	dict, ok := value.(Dict[string, any])
+	if !ok {
+		return fmt.Errorf("cannot decode value of type %T into a dictionary", value)
+	}
+	keys := dict.KeysSlice()
+	sortKeys(keys)
+	result := ego.empty(len(keys))
+	for _, key := range keys {
+		convertedKey, err := convertKey[K](key)
+		if err != nil {
+			return err
+		}
+		converted, err := convertValue[V](dict.getVal()[key])
+		if err != nil {
+			return fmt.Errorf("field %q: %w", key, err)
+		}
+		if err := result.SetE(convertedKey, converted); err != nil {
+			return fmt.Errorf("field %q: %w", key, err)
 		}
 	}
-	result += "}"
-	return result
+	ego.val, ego.order, ego.shared = result.val, result.order, false
+	return nil
+}
+
+/*
+Converts the dictionary into a Go map, nested collections become Go maps and slices.
+
+Returns:
+  - map of fields, nil for a nil dictionary.
+*/
+func (ego *mapDict[K, V]) goValue() any {
+	if ego == nil {
+		return nil
+	}
+	fields := make(map[K]any, len(ego.getVal()))
+	for key, value := range ego.getVal() {
+		fields[key] = toNative(value)
+	}
+	return fields
 }
 
 func (ego *mapDict[K, V]) GoMap() map[K]V {
 	ego.assert()
+	ego.shared = true
 	return ego.getVal()
 }
 
 func (ego *mapDict[K, V]) Keys() List[K] {
-	keys := NewList[K]()
-	for key := range ego.getVal() {
-		keys.Add(key)
-	}
-	return keys
+	return &sliceList[K]{val: ego.KeysSlice()}
 }
 
 func (ego *mapDict[K, V]) Values() List[V] {
-	values := NewList[V]()
-	for _, value := range ego.getVal() {
-		values.Add(value)
-	}
+	return &sliceList[V]{val: ego.ValuesSlice()}
+}
+
+func (ego *mapDict[K, V]) Entries() List[Entry[K, V]] {
+	entries := make([]Entry[K, V], 0, len(ego.getVal()))
+	ego.each(func(key K, value V) {
+		entries = append(entries, Entry[K, V]{key, value})
+	})
+	return &sliceList[Entry[K, V]]{val: entries}
+}
+
+func (ego *mapDict[K, V]) Pairs() List[Pair[K, V]] {
+	pairs := make([]Pair[K, V], 0, len(ego.getVal()))
+	ego.each(func(key K, value V) {
+		pairs = append(pairs, Pair[K, V]{key, value})
+	})
+	return &sliceList[Pair[K, V]]{val: pairs}
+}
+
+func (ego *mapDict[K, V]) KeysSlice() []K {
+	keys := make([]K, 0, len(ego.getVal()))
+	ego.each(func(key K, _ V) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+func (ego *mapDict[K, V]) ValuesSlice() []V {
+	values := make([]V, 0, len(ego.getVal()))
+	ego.each(func(_ K, value V) {
+		values = append(values, value)
+	})
 	return values
 }
 
+func (ego *mapDict[K, V]) SortedKeys() List[K] {
+	return ego.Keys().Sort()
+}
+
+func (ego *mapDict[K, V]) SortedValues() List[V] {
+	return ego.Values().Sort()
+}
+
 func (ego *mapDict[K, V]) Clone() Dict[K, V] {
-	obj := NewDict[K, V]()
-	for key, value := range ego.getVal() {
+	ego.assert()
+	obj := ego.empty(len(ego.getVal()))
+	ego.each(func(key K, value V) {
 		obj.Set(key, value)
-	}
+	})
 	return obj
 }
 
-func (ego *mapDict[K, V]) Count() int {
+func (ego *mapDict[K, V]) DeepClone() Dict[K, V] {
 	ego.assert()
+	obj := ego.empty(ego.Count())
+	ego.each(func(key K, value V) {
+		obj.Set(key, deepCopy(value))
+	})
+	return obj
+}
+
+func (ego *mapDict[K, V]) deepClone() any {
+	return ego.DeepClone()
+}
+
+func (ego *mapDict[K, V]) Count() int {
 	return len(ego.getVal())
 }
 
@@ -379,37 +1320,148 @@ func (ego *mapDict[K, V]) Empty() bool {
 }
 
 func (ego *mapDict[K, V]) Equals(another Dict[K, V]) bool {
+	if another == nil {
+		return ego.Count() == 0
+	}
 	if ego.Count() != another.Count() {
 		return false
 	}
-	for k := range ego.getVal() {
-		if ego.getVal()[k] != another.getVal()[k] {
+	if reflect.ValueOf(ego.getVal()).Pointer() == reflect.ValueOf(another.getVal()).Pointer() {
+		return true
+	}
+	for key, value := range ego.getVal() {
+		if item, ok := another.getVal()[key]; !ok || item != value {
 			return false
 		}
 	}
 	return true
 }
 
-func (ego *mapDict[K, V]) Merge(another Dict[K, V]) Dict[K, V] {
+func (ego *mapDict[K, V]) Merge(others ...Dict[K, V]) Dict[K, V] {
 	ego.assert()
-	result := ego.Clone()
-	another.ForEach(func(key K, val V) {
+	count := ego.Count()
+	for _, another := range others {
+		if another != nil {
+			count += another.Count()
+		}
+	}
+	result := ego.empty(count)
+	ego.each(func(key K, val V) {
 		result.Set(key, val)
 	})
+	for _, another := range others {
+		if another != nil {
+			another.ForEach(func(key K, val V) {
+				result.Set(key, val)
+			})
+		}
+	}
+	return result
+}
+
+func (ego *mapDict[K, V]) SymmetricDifference(another Dict[K, V]) Dict[K, V] {
+	ego.assert()
+	if another == nil {
+		return ego.Merge()
+	}
+	result := ego.empty(0)
+	ego.each(func(key K, val V) {
+		if !another.KeyExists(key) {
+			result.Set(key, val)
+		}
+	})
+	another.ForEach(func(key K, val V) {
+		if !ego.KeyExists(key) {
+			result.Set(key, val)
+		}
+	})
 	return result
 }
 
 func (ego *mapDict[K, V]) Pluck(keys ...K) Dict[K, V] {
 	ego.assert()
-	result := NewDict[K, V]()
+	result := ego.empty(len(keys))
 	for _, key := range keys {
 		result.Set(key, ego.Get(key))
 	}
 	return result
 }
 
-func (ego *mapDict[K, V]) Contains(value V) bool {
+func (ego *mapDict[K, V]) CloneExcept(keys ...K) Dict[K, V] {
+	ego.assert()
+	excluded := make(map[K]struct{}, len(keys))
+	for _, key := range keys {
+		excluded[key] = struct{}{}
+	}
+	result := ego.empty(len(ego.getVal()))
+	ego.each(func(key K, value V) {
+		if _, ok := excluded[key]; !ok {
+			result.Set(key, value)
+		}
+	})
+	return result
+}
+
+/*
+Acquires keys of the dictionary in an order suitable for a random choice.
+Keys of an unordered dictionary are sorted if a seeded source of randomness is given, so the choice is reproducible.
+
+Parameters:
+  - r - source of randomness (the global one if nil).
+
+Returns:
+  - slice of keys.
+*/
+func (ego *mapDict[K, V]) sampledKeys(r *rand.Rand) []K {
+	keys := ego.KeysSlice()
+	if r != nil && !ego.ordered {
+		sortKeys(keys)
+	}
+	return keys
+}
+
+func (ego *mapDict[K, V]) SampleKey(r *rand.Rand) K {
 	ego.assert()
+	if ego.Empty() {
+		panic(ErrEmpty)
+	}
+	keys := ego.sampledKeys(r)
+	return keys[randomIndex(len(keys), r)]
+}
+
+func (ego *mapDict[K, V]) SampleEntry(r *rand.Rand) (K, V) {
+	key := ego.SampleKey(r)
+	return key, ego.val[key]
+}
+
+func (ego *mapDict[K, V]) SampleN(n int, r *rand.Rand) Dict[K, V] {
+	ego.assert()
+	if n < 0 {
+		panic(invalidArgument("sample size %d cannot be negative", n))
+	}
+	if n >= ego.Count() {
+		return ego.Clone()
+	}
+	keys := ego.sampledKeys(r)
+	indices := make([]int, len(keys))
+	for i := range indices {
+		indices[i] = i
+	}
+	// partial Fisher-Yates shuffle, the first n indices are a uniform sample
+	for i := 0; i < n; i++ {
+		j := i + randomIndex(len(indices)-i, r)
+		indices[i], indices[j] = indices[j], indices[i]
+	}
+	chosen := indices[:n]
+	slices.Sort(chosen)
+	result := ego.empty(n)
+	for _, index := range chosen {
+		result.Set(keys[index], ego.val[keys[index]])
+	}
+	return result
+}
+
+func (ego *mapDict[K, V]) Contains(value V) bool {
 	for _, item := range ego.getVal() {
 		if item == value {
 			return true
@@ -419,34 +1471,142 @@ func (ego *mapDict[K, V]) Contains(value V) bool {
 }
 
 func (ego *mapDict[K, V]) KeyOf(value V) K {
-	ego.assert()
+	key, err := ego.KeyOfE(value)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+func (ego *mapDict[K, V]) KeyOfE(value V) (K, error) {
+	var zero K
 	for key, item := range ego.getVal() {
 		if item == value {
-			return key
+			return key, nil
 		}
 	}
-	panic(fmt.Sprintf("value %s not found", toString(value)))
+	return zero, ErrValueNotFound{value}
 }
 
 func (ego *mapDict[K, V]) KeyExists(key K) bool {
-	ego.assert()
 	_, ok := ego.getVal()[key]
 	return ok
 }
 
 func (ego *mapDict[K, V]) ForEach(function func(K, V)) Dict[K, V] {
+	ego.each(function)
+	return ego
+}
+
+func (ego *mapDict[K, V]) ForEachKey(function func(K)) Dict[K, V] {
+	ego.each(func(key K, _ V) {
+		function(key)
+	})
+	return ego
+}
+
+func (ego *mapDict[K, V]) ForEachSorted(function func(K, V)) Dict[K, V] {
+	ego.SortedKeys().ForEach(func(key K) {
+		function(key, ego.getVal()[key])
+	})
+	return ego
+}
+
+func (ego *mapDict[K, V]) ForEachValue(function func(V)) Dict[K, V] {
+	ego.each(func(_ K, value V) {
+		function(value)
+	})
+	return ego
+}
+
+func (ego *mapDict[K, V]) Tap(function func(K, V)) Dict[K, V] {
+	return ego.ForEach(function)
+}
+
+func (ego *mapDict[K, V]) Map(function func(K, V) V) Dict[K, V] {
+	ego.assert()
+	result := ego.empty(ego.Count())
+	ego.each(func(key K, item V) {
+		result.Set(key, function(key, item))
+	})
+	return result
+}
+
+func (ego *mapDict[K, V]) MapParallel(function func(K, V) V, workers int) Dict[K, V] {
 	ego.assert()
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	result := ego.empty(ego.Count())
+	if ego.ordered {
+		// keys stay the same, so the result keeps the insertion order of the original
+		result.order = append(result.order, ego.order...)
+	}
+	jobs := make(chan Pair[K, V])
+	results := make(chan Pair[K, V])
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				results <- Pair[K, V]{job.first, function(job.first, job.second)}
+			}
+		}()
+	}
+	collected := make(chan struct{})
+	go func() {
+		for field := range results {
+			result.val[field.first] = field.second
+		}
+		close(collected)
+	}()
 	for key, item := range ego.getVal() {
-		function(key, item)
+		jobs <- Pair[K, V]{key, item}
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+	<-collected
+	for key, item := range result.val {
+		if err := result.check(key, item); err != nil {
+			panic(err)
+		}
+	}
+	return result
+}
+
+func (ego *mapDict[K, V]) MapInPlace(function func(K, V) V) Dict[K, V] {
+	ego.assert()
+	for key, item := range ego.getVal() {
+		mapped := function(key, item)
+		if err := ego.check(key, mapped); err != nil {
+			panic(err)
+		}
+		ego.getVal()[key] = mapped
 	}
 	return ego
 }
 
-func (ego *mapDict[K, V]) Map(function func(K, V) V) Dict[K, V] {
+func (ego *mapDict[K, V]) MapValuesInPlace(function func(V) V) Dict[K, V] {
 	ego.assert()
-	result := NewDict[K, V]()
 	for key, item := range ego.getVal() {
-		result.Set(key, function(key, item))
+		mapped := function(item)
+		if err := ego.check(key, mapped); err != nil {
+			panic(err)
+		}
+		ego.getVal()[key] = mapped
 	}
-	return result
+	return ego
+}
+
+func (ego *mapDict[K, V]) Pipe(function func(Dict[K, V]) Dict[K, V]) Dict[K, V] {
+	ego.assert()
+	return function(ego)
+}
+
+func (ego *mapDict[K, V]) Inspect(function func(Dict[K, V])) Dict[K, V] {
+	ego.assert()
+	function(ego)
+	return ego
 }
@@ -0,0 +1,185 @@
+/*
+Collection Library for Go
+Error types
+*/
+package collection
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+var (
+	// ErrNotInitialized is a panic value of operations on a nil or uninitialized collection.
+	// Lists, dictionaries, sets and LRU caches panic with errors of their own messages matching it by errors.Is.
+	ErrNotInitialized = errors.New("collection is not initialized")
+
+	// ErrNotNumeric is a panic value of numeric operations on a list whose elements are neither int nor float64.
+	ErrNotNumeric = errors.New("list type is neither int or float64")
+
+	// ErrNotSortable is a panic value of sorting a list whose elements have no natural order.
+	ErrNotSortable = errors.New("unsortable list")
+
+	// ErrEmpty is a panic value of operations requiring at least one element on an empty collection.
+	// Pop panics with an error of its own message matching it by errors.Is.
+	ErrEmpty = errors.New("collection is empty")
+
+	// ErrNoMatch is a panic value of searches for a value satisfying a condition which no value satisfies.
+	ErrNoMatch = errors.New("no value satisfies the condition")
+
+	// ErrInvalidArgument is matched by errors.Is by panics of calls with an invalid argument, e.g. a negative length.
+	// Each such panic has its own message describing the argument.
+	ErrInvalidArgument = errors.New("invalid argument")
+)
+
+var (
+	errListNotInitialized = kindError{ErrNotInitialized, "list is not initialized."}
+	errDictNotInitialized = kindError{ErrNotInitialized, "dictionary is not initialized"}
+	errPopEmpty           = kindError{ErrEmpty, "cannot pop from an empty list"}
+
+	errSetNotInitialized      = kindError{ErrNotInitialized, "set is not initialized"}
+	errLRUCacheNotInitialized = kindError{ErrNotInitialized, "LRU cache is not initialized"}
+)
+
+/*
+Error of a particular kind of collection or operation.
+Keeps its own message while matching a common sentinel error by errors.Is.
+*/
+type kindError struct {
+	err     error
+	message string
+}
+
+/*
+Describes the error.
+
+Returns:
+  - error message.
+*/
+func (ego kindError) Error() string {
+	return ego.message
+}
+
+/*
+Gives the common sentinel error.
+
+Returns:
+  - sentinel error.
+*/
+func (ego kindError) Unwrap() error {
+	return ego.err
+}
+
+/*
+Creates an error of an invalid argument matching ErrInvalidArgument.
+
+Parameters:
+  - format - format of the message,
+  - args... - arguments of the format.
+
+Returns:
+  - error with the formatted message.
+*/
+func invalidArgument(format string, args ...any) error {
+	return kindError{ErrInvalidArgument, fmt.Sprintf(format, args...)}
+}
+
+/*
+Error of an access to a key which does not exist in a dictionary.
+*/
+type ErrKeyNotFound struct {
+	Key any
+}
+
+/*
+Describes the error.
+
+Returns:
+  - error message.
+*/
+func (ego ErrKeyNotFound) Error() string {
+	return fmt.Sprintf("key %s does not exist", toString(ego.Key))
+}
+
+/*
+Error of a search for a value which is not contained in a dictionary.
+*/
+type ErrValueNotFound struct {
+	Value any
+}
+
+/*
+Describes the error.
+
+Returns:
+  - error message.
+*/
+func (ego ErrValueNotFound) Error() string {
+	return fmt.Sprintf("value %s not found", toString(ego.Value))
+}
+
+/*
+Error of an access to a position which is out of range of a list.
+*/
+type ErrIndexOutOfRange struct {
+	Index int
+	Len   int
+}
+
+/*
+Describes the error.
+
+Returns:
+  - error message.
+*/
+func (ego ErrIndexOutOfRange) Error() string {
+	return fmt.Sprintf("index %d out of range with count %d", ego.Index, ego.Len)
+}
+
+/*
+Error of a range of a list whose starting index is higher than its ending index.
+Both indexes are already converted from negative values.
+*/
+type ErrInvalidRange struct {
+	Start int
+	End   int
+}
+
+/*
+Describes the error.
+
+Returns:
+  - error message.
+*/
+func (ego ErrInvalidRange) Error() string {
+	return fmt.Sprintf("starting index %d is higher than the ending index %d", ego.Start, ego.End)
+}
+
+/*
+Calls a function and returns the error the collection panicked with inside it instead of propagating the panic.
+Errors of invalid arguments are returned as well (ErrInvalidArgument),
+panics which are not errors and runtime errors are propagated unchanged.
+It is the non-panicking counterpart of the methods which have no variant with an E suffix.
+
+Parameters:
+  - function - function operating on collections.
+
+Returns:
+  - error the function panicked with, nil if it returned normally.
+*/
+func Try(function func()) (err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			if e, ok := value.(error); ok {
+				if _, isRuntime := e.(runtime.Error); !isRuntime {
+					err = e
+					return
+				}
+			}
+			panic(value)
+		}
+	}()
+	function()
+	return nil
+}
@@ -0,0 +1,59 @@
+package collection
+
+/*
+Creates a nil dictionary, a nil pointer wrapped in the Dict interface.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+
+Returns:
+  - nil dictionary.
+*/
+func NilDict[K comparable, V comparable]() Dict[K, V] {
+	var ego *mapDict[K, V]
+	return ego
+}
+
+/*
+Creates a nil list, a nil pointer wrapped in the List interface.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - nil list.
+*/
+func NilList[T comparable]() List[T] {
+	var ego *sliceList[T]
+	return ego
+}
+
+/*
+Creates a nil LRU cache, a nil pointer wrapped in the LRUCache interface.
+
+Type parameters:
+  - K - type of keys,
+  - V - type of values.
+
+Returns:
+  - nil cache.
+*/
+func NilLRUCache[K comparable, V comparable]() LRUCache[K, V] {
+	var ego *lruCache[K, V]
+	return ego
+}
+
+/*
+Creates a nil set, a nil pointer wrapped in the Set interface.
+
+Type parameters:
+  - T - type of set elements.
+
+Returns:
+  - nil set.
+*/
+func NilSet[T comparable]() Set[T] {
+	var ego *mapSet[T]
+	return ego
+}
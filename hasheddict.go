@@ -0,0 +1,364 @@
+/*
+Collection Library for Go
+Dictionary type with keys of any type
+*/
+package collection
+
+import "strings"
+
+/*
+Hashed dictionary, unordered set of key-value pairs with keys of any type.
+Keys do not have to be comparable, they are distributed into buckets by a hash function
+and compared by an equality function within a bucket.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+*/
+type HashedDict[K any, V comparable] interface {
+	Collection
+
+	/*
+		Asserts that the dictionary is initialized.
+	*/
+	assert()
+
+	/*
+		Finds a field with a given key.
+
+		Parameters:
+		  - key - key to find.
+
+		Returns:
+		  - hash of the key,
+		  - position of the field in the bucket (-1 if the key does not exist).
+	*/
+	find(key K) (string, int)
+
+	/*
+		Sets the value of the field of the dictionary.
+		If the key already exists, the value is overwritten, if not, a new field is created.
+
+		Parameters:
+		  - key - key to set,
+		  - value - new value to be set.
+
+		Returns:
+		  - updated dictionary.
+	*/
+	Set(key K, value V) HashedDict[K, V]
+
+	/*
+		Deletes the fields with given keys.
+
+		Parameters:
+		  - keys... - any amount of keys to delete.
+
+		Returns:
+		  - updated dictionary.
+	*/
+	Unset(keys ...K) HashedDict[K, V]
+
+	/*
+		Deletes all fields in the dictionary.
+
+		Returns:
+		  - updated dictionary.
+	*/
+	Clear() HashedDict[K, V]
+
+	/*
+		Acquires the value under the specified key of the dictionary.
+
+		Parameters:
+		  - key - key of the field to get.
+
+		Returns:
+		  - corresponding value.
+	*/
+	Get(key K) V
+
+	/*
+		Serializes the dictionary.
+		Keys are serialized the same way as values, so the output is a valid JSON only for string keys.
+
+		Returns:
+		  - string representing the serialized dictionary.
+	*/
+	String() string
+
+	/*
+		Convers the dictionary to a list of its keys.
+		The keys are the original values, not their hashes.
+
+		Returns:
+		  - list of keys of the dictionary.
+	*/
+	Keys() AnyList[K]
+
+	/*
+		Convers the dictionary to a list of its values.
+
+		Returns:
+		  - list of values of the dictionary.
+	*/
+	Values() List[V]
+
+	/*
+		Creates a copy of the dictionary.
+		The copy uses the same hash and equality functions.
+
+		Returns:
+		  - copied dictionary.
+	*/
+	Clone() HashedDict[K, V]
+
+	/*
+		Gives a number of fields in the dictionary.
+
+		Returns:
+		  - number of fields.
+	*/
+	Count() int
+
+	/*
+		Checks whether the dictionary is empty.
+
+		Returns:
+		  - true if the dictionary is empty, false otherwise.
+	*/
+	Empty() bool
+
+	/*
+		Checks if the content of the dictionary is equal to the content of another dictionary.
+		Keys are looked up by the hash and equality functions of another dictionary,
+		so both dictionaries should use equivalent functions.
+
+		Parameters:
+		  - another - a dictionary to compare with.
+
+		Returns:
+		  - true if the dictionaries are equal, false otherwise.
+	*/
+	Equals(another HashedDict[K, V]) bool
+
+	/*
+		Checks if a given key exists within the dictionary.
+
+		Parameters:
+		  - key - the key to check.
+
+		Returns:
+		  - true if the key exists, false otherwise.
+	*/
+	KeyExists(key K) bool
+
+	/*
+		Executes a given function over an every field of the dictionary.
+		The function has two parameters: key of the current field and its value.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged dictionary.
+	*/
+	ForEach(function func(k K, v V)) HashedDict[K, V]
+}
+
+/*
+Field of a hashed dictionary.
+
+Type parameters:
+  - K - type of the key,
+  - V - type of the value.
+*/
+type hashedField[K any, V comparable] struct {
+	key   K
+	value V
+}
+
+/*
+Hashed dictionary, a reference type. Contains a map of buckets of fields indexed by key hashes.
+
+Implements:
+  - HashedDict.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+*/
+type bucketDict[K any, V comparable] struct {
+	val   map[string][]hashedField[K, V]
+	count int
+	hash  func(K) string
+	eq    func(K, K) bool
+}
+
+/*
+Hashed dictionary constructor.
+Creates a new dictionary with keys of any type.
+Keys with an equal hash are distinguished by the equality function,
+so the hash function does not have to be collision-free, but equal keys must have equal hashes.
+
+Parameters:
+  - hash - function computing a hash of a key,
+  - eq - function checking whether two keys are equal.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+
+Returns:
+  - pointer to the created dictionary.
+*/
+func NewHashedDict[K any, V comparable](hash func(K) string, eq func(a K, b K) bool) HashedDict[K, V] {
+	return &bucketDict[K, V]{make(map[string][]hashedField[K, V]), 0, hash, eq}
+}
+
+func (ego *bucketDict[K, V]) serialize(b *strings.Builder) {
+	b.Grow(8 * ego.count)
+	b.WriteByte('{')
+	first := true
+	ego.ForEach(func(key K, value V) {
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		writeString(b, key)
+		b.WriteByte(':')
+		writeString(b, value)
+	})
+	b.WriteByte('}')
+}
+
+func (ego *bucketDict[K, V]) assert() {
+	if ego == nil || ego.val == nil {
+		panic(errDictNotInitialized)
+	}
+}
+
+func (ego *bucketDict[K, V]) find(key K) (string, int) {
+	hash := ego.hash(key)
+	for i, field := range ego.val[hash] {
+		if ego.eq(field.key, key) {
+			return hash, i
+		}
+	}
+	return hash, -1
+}
+
+func (ego *bucketDict[K, V]) Set(key K, value V) HashedDict[K, V] {
+	ego.assert()
+	hash, i := ego.find(key)
+	if i >= 0 {
+		ego.val[hash][i].value = value
+		return ego
+	}
+	ego.val[hash] = append(ego.val[hash], hashedField[K, V]{key, value})
+	ego.count++
+	return ego
+}
+
+func (ego *bucketDict[K, V]) Unset(keys ...K) HashedDict[K, V] {
+	ego.assert()
+	for _, key := range keys {
+		hash, i := ego.find(key)
+		if i < 0 {
+			panic(ErrKeyNotFound{key})
+		}
+		bucket := ego.val[hash]
+		if len(bucket) == 1 {
+			delete(ego.val, hash)
+		} else {
+			ego.val[hash] = append(bucket[:i:i], bucket[i+1:]...)
+		}
+		ego.count--
+	}
+	return ego
+}
+
+func (ego *bucketDict[K, V]) Clear() HashedDict[K, V] {
+	ego.assert()
+	ego.val = make(map[string][]hashedField[K, V])
+	ego.count = 0
+	return ego
+}
+
+func (ego *bucketDict[K, V]) Get(key K) V {
+	ego.assert()
+	hash, i := ego.find(key)
+	if i < 0 {
+		panic(ErrKeyNotFound{key})
+	}
+	return ego.val[hash][i].value
+}
+
+func (ego *bucketDict[K, V]) String() string {
+	var b strings.Builder
+	ego.serialize(&b)
+	return b.String()
+}
+
+func (ego *bucketDict[K, V]) Keys() AnyList[K] {
+	keys := &sliceAnyList[K]{make([]K, 0, ego.Count())}
+	ego.ForEach(func(key K, _ V) {
+		keys.Add(key)
+	})
+	return keys
+}
+
+func (ego *bucketDict[K, V]) Values() List[V] {
+	values := &sliceList[V]{val: make([]V, 0, ego.Count())}
+	ego.ForEach(func(_ K, value V) {
+		values.Add(value)
+	})
+	return values
+}
+
+func (ego *bucketDict[K, V]) Clone() HashedDict[K, V] {
+	ego.assert()
+	result := &bucketDict[K, V]{make(map[string][]hashedField[K, V], len(ego.val)), ego.count, ego.hash, ego.eq}
+	for hash, bucket := range ego.val {
+		result.val[hash] = append([]hashedField[K, V](nil), bucket...)
+	}
+	return result
+}
+
+func (ego *bucketDict[K, V]) Count() int {
+	ego.assert()
+	return ego.count
+}
+
+func (ego *bucketDict[K, V]) Empty() bool {
+	return ego.Count() == 0
+}
+
+func (ego *bucketDict[K, V]) Equals(another HashedDict[K, V]) bool {
+	if ego.Count() != another.Count() {
+		return false
+	}
+	equal := true
+	ego.ForEach(func(key K, value V) {
+		if equal && (!another.KeyExists(key) || another.Get(key) != value) {
+			equal = false
+		}
+	})
+	return equal
+}
+
+func (ego *bucketDict[K, V]) KeyExists(key K) bool {
+	ego.assert()
+	_, i := ego.find(key)
+	return i >= 0
+}
+
+func (ego *bucketDict[K, V]) ForEach(function func(K, V)) HashedDict[K, V] {
+	ego.assert()
+	for _, bucket := range ego.val {
+		for _, field := range bucket {
+			function(field.key, field.value)
+		}
+	}
+	return ego
+}
@@ -0,0 +1,251 @@
+/*
+Collection Library for Go
+Indexed list type
+*/
+package collection
+
+import "strings"
+
+/*
+Indexed list, a read-only snapshot of a list with constant-time lookups.
+Positions and counts of all elements are precomputed, so Contains, IndexOf and CountOf do not scan the elements.
+Intended for lists which are searched repeatedly but rarely modified.
+
+Type parameters:
+  - T - type of list elements.
+*/
+type IndexedList[T comparable] interface {
+	Collection
+
+	/*
+		Asserts that the list is initialized.
+	*/
+	assert()
+
+	/*
+		Acquires the element of the list at a specified position.
+		Panics if the index is out of range.
+
+		Parameters:
+		  - index - position of the element.
+
+		Returns:
+		  - element at the position.
+	*/
+	Get(index int) T
+
+	/*
+		Serializes the list.
+		If only compatible types are used, the output will be a valid JSON.
+
+		Returns:
+		  - string representing the serialized list.
+	*/
+	String() string
+
+	/*
+		Converts the list into a Go slice.
+		The slice is a copy, modifying it does not affect the list.
+
+		Returns:
+		  - slice.
+	*/
+	GoSlice() []T
+
+	/*
+		Converts the indexed list back to an ordinary list.
+		The new list is a copy and can be modified freely.
+
+		Returns:
+		  - new list.
+	*/
+	List() List[T]
+
+	/*
+		Gives a number of elements in the list.
+
+		Returns:
+		  - number of elements.
+	*/
+	Count() int
+
+	/*
+		Checks whether the list is empty.
+
+		Returns:
+		  - true if the list has no elements, false otherwise.
+	*/
+	Empty() bool
+
+	/*
+		Checks if the list contains a given element.
+		Runs in constant time.
+
+		Parameters:
+		  - elem - the element to check.
+
+		Returns:
+		  - true if the list contains the element, false otherwise.
+	*/
+	Contains(elem T) bool
+
+	/*
+		Gives a position of the first occurrence of a given element.
+		Runs in constant time.
+
+		Parameters:
+		  - elem - the element to check.
+
+		Returns:
+		  - index of the element (-1 if the list does not contain the element).
+	*/
+	IndexOf(elem T) int
+
+	/*
+		Gives a number of occurrences of a given element.
+		Runs in constant time.
+
+		Parameters:
+		  - elem - the element to count.
+
+		Returns:
+		  - number of occurrences (0 if the list does not contain the element).
+	*/
+	CountOf(elem T) int
+
+	/*
+		Executes a given function over an every element of the list.
+		The function has one parameter, the current element.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged list.
+	*/
+	ForEach(function func(x T)) IndexedList[T]
+}
+
+/*
+Position and number of occurrences of an element of an indexed list.
+*/
+type indexEntry struct {
+	first int
+	count int
+}
+
+/*
+Indexed list, a reference type. Contains a slice of elements and a map of their positions.
+
+Implements:
+  - IndexedList.
+
+Type parameters:
+  - T - type of list elements.
+*/
+type sliceIndexedList[T comparable] struct {
+	val   []T
+	index map[T]indexEntry
+}
+
+/*
+Indexed list constructor.
+Creates a read-only snapshot of a given list with precomputed lookups.
+Building the index takes linear time and memory, later changes of the original list are not reflected.
+
+Parameters:
+  - list - list to index.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - pointer to the created indexed list.
+*/
+func NewIndexedList[T comparable](list List[T]) IndexedList[T] {
+	list.assert()
+	ego := &sliceIndexedList[T]{
+		make([]T, list.Count()),
+		make(map[T]indexEntry, list.Count()),
+	}
+	copy(ego.val, list.getVal())
+	for i, item := range ego.val {
+		entry, ok := ego.index[item]
+		if !ok {
+			entry.first = i
+		}
+		entry.count++
+		ego.index[item] = entry
+	}
+	return ego
+}
+
+func (ego *sliceIndexedList[T]) serialize(b *strings.Builder) {
+	(&sliceList[T]{val: ego.val}).serialize(b)
+}
+
+func (ego *sliceIndexedList[T]) assert() {
+	if ego == nil || ego.val == nil {
+		panic(errListNotInitialized)
+	}
+}
+
+func (ego *sliceIndexedList[T]) Get(index int) T {
+	ego.assert()
+	if index < 0 || index >= len(ego.val) {
+		panic(ErrIndexOutOfRange{index, len(ego.val)})
+	}
+	return ego.val[index]
+}
+
+func (ego *sliceIndexedList[T]) String() string {
+	ego.assert()
+	var b strings.Builder
+	ego.serialize(&b)
+	return b.String()
+}
+
+func (ego *sliceIndexedList[T]) GoSlice() []T {
+	ego.assert()
+	return append(make([]T, 0, len(ego.val)), ego.val...)
+}
+
+func (ego *sliceIndexedList[T]) List() List[T] {
+	return &sliceList[T]{val: ego.GoSlice()}
+}
+
+func (ego *sliceIndexedList[T]) Count() int {
+	ego.assert()
+	return len(ego.val)
+}
+
+func (ego *sliceIndexedList[T]) Empty() bool {
+	return ego.Count() == 0
+}
+
+func (ego *sliceIndexedList[T]) Contains(elem T) bool {
+	ego.assert()
+	_, ok := ego.index[elem]
+	return ok
+}
+
+func (ego *sliceIndexedList[T]) IndexOf(elem T) int {
+	ego.assert()
+	if entry, ok := ego.index[elem]; ok {
+		return entry.first
+	}
+	return -1
+}
+
+func (ego *sliceIndexedList[T]) CountOf(elem T) int {
+	ego.assert()
+	return ego.index[elem].count
+}
+
+func (ego *sliceIndexedList[T]) ForEach(function func(T)) IndexedList[T] {
+	ego.assert()
+	for _, item := range ego.val {
+		function(item)
+	}
+	return ego
+}
@@ -5,18 +5,29 @@ List type
 package collection
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
+	"iter"
 	"math"
-	"sort"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
 )
 
 /*
 List, an ordered sequence of elements.
+Like a nil Go slice, a nil or uninitialized list behaves as an empty one for reading
+(Count, Empty, Contains, IndexOf, CountOf, GetE, String, JSONString, ForEach, ForEachIndexed, ForEachBatched and Equals),
+while modifications panic with ErrNotInitialized.
+Invalid arguments (e.g. a negative length) cause panics matching ErrInvalidArgument, which Try turns into errors.
 
 Type parameters:
   - T - type of list elements.
 */
 type List[T comparable] interface {
+	Collection
 
 	/*
 		Acquires the value of the list.
@@ -47,6 +58,54 @@ type List[T comparable] interface {
 	*/
 	Add(val ...T) List[T]
 
+	/*
+		Inserts new elements at the end of the list.
+		Unlike Add, returns an error instead of panicking if some element is rejected by the validator of the list.
+		In that case, no element is added.
+
+		Parameters:
+		  - values... - any amount of elements to add.
+
+		Returns:
+		  - error returned by the validator, ErrNotInitialized if the list is not initialized, nil otherwise.
+	*/
+	AddE(values ...T) error
+
+	/*
+		Inserts all elements of another list at the end of the list.
+		The list grows at most once.
+
+		Parameters:
+		  - another - a list to append.
+
+		Returns:
+		  - updated list.
+	*/
+	AddList(another List[T]) List[T]
+
+	/*
+		Inserts all elements of a Go slice at the end of the list.
+		The list grows at most once.
+
+		Parameters:
+		  - slice - a slice to append.
+
+		Returns:
+		  - updated list.
+	*/
+	AddSlice(slice []T) List[T]
+
+	/*
+		Inserts all elements of another list at the beginning of the list.
+
+		Parameters:
+		  - another - a list to prepend.
+
+		Returns:
+		  - updated list.
+	*/
+	PrependList(another List[T]) List[T]
+
 	/*
 		Inserts a new element at the specified position in the list.
 
@@ -71,6 +130,43 @@ type List[T comparable] interface {
 	*/
 	Replace(index int, value T) List[T]
 
+	/*
+		Replaces all occurrences of a given element with a new one.
+
+		Parameters:
+		  - old - element which should be replaced,
+		  - new - new element.
+
+		Returns:
+		  - updated list.
+	*/
+	ReplaceAll(old T, new T) List[T]
+
+	/*
+		Replaces all elements satisfying a condition with a new one.
+
+		Parameters:
+		  - function - anonymous function returning true for the elements which should be replaced,
+		  - new - new element.
+
+		Returns:
+		  - updated list.
+	*/
+	ReplaceWhere(function func(x T) bool, new T) List[T]
+
+	/*
+		Creates a new list with all occurrences of a given element replaced with a new one.
+		The old list remains unchanged.
+
+		Parameters:
+		  - old - element which should be replaced,
+		  - new - new element.
+
+		Returns:
+		  - new list.
+	*/
+	ReplaceAllClone(old T, new T) List[T]
+
 	/*
 		Deletes the elements at the specified positions in the list.
 
@@ -82,6 +178,19 @@ type List[T comparable] interface {
 	*/
 	Delete(index ...int) List[T]
 
+	/*
+		Deletes the elements at the specified positions in the list.
+		Unlike Delete, returns an error instead of panicking.
+		Nothing is deleted if some of the positions is out of range, repeated positions are deleted once.
+
+		Parameters:
+		  - indexes... - any amount of positions of the elements to delete.
+
+		Returns:
+		  - ErrIndexOutOfRange if some of the positions is out of range, ErrNotInitialized if the list is not initialized, nil otherwise.
+	*/
+	DeleteE(index ...int) error
+
 	/*
 		Deletes the last element in the list and returns it.
 
@@ -90,6 +199,16 @@ type List[T comparable] interface {
 	*/
 	Pop() T
 
+	/*
+		Deletes the last element in the list and returns it.
+		Unlike Pop, returns an error instead of panicking.
+
+		Returns:
+		  - popped element,
+		  - ErrEmpty if the list is empty, ErrNotInitialized if the list is not initialized, nil otherwise.
+	*/
+	PopE() (T, error)
+
 	/*
 		Deletes all elements in the list.
 
@@ -98,6 +217,57 @@ type List[T comparable] interface {
 	*/
 	Clear() List[T]
 
+	/*
+		Overwrites all elements of the list with a given value, the length of the list is kept.
+
+		Parameters:
+		  - value - new value of the elements.
+
+		Returns:
+		  - updated list.
+	*/
+	Fill(value T) List[T]
+
+	/*
+		Extends the list at the end to a given length by a fill value.
+		Does nothing if the list is already long enough.
+
+		Parameters:
+		  - length - minimal length of the list,
+		  - fill - value of the new elements.
+
+		Returns:
+		  - updated list.
+	*/
+	PadRight(length int, fill T) List[T]
+
+	/*
+		Extends the list at the beginning to a given length by a fill value.
+		Does nothing if the list is already long enough.
+
+		Parameters:
+		  - length - minimal length of the list,
+		  - fill - value of the new elements.
+
+		Returns:
+		  - updated list.
+	*/
+	PadLeft(length int, fill T) List[T]
+
+	/*
+		Changes the length of the list.
+		Shorter list is extended at the end by a fill value, longer list is truncated.
+		Panics if the length is negative.
+
+		Parameters:
+		  - length - new length of the list,
+		  - fill - value of the new elements.
+
+		Returns:
+		  - updated list.
+	*/
+	Resize(length int, fill T) List[T]
+
 	/*
 		Acquires the element at the specified position in the list.
 
@@ -109,6 +279,19 @@ type List[T comparable] interface {
 	*/
 	Get(index int) T
 
+	/*
+		Acquires the element at the specified position in the list.
+		Unlike Get, returns an error instead of panicking.
+
+		Parameters:
+		  - index - position of the element to get.
+
+		Returns:
+		  - corresponding value (zero value in case of an error),
+		  - ErrIndexOutOfRange if the position is out of range (a nil list behaves as an empty one), nil otherwise.
+	*/
+	GetE(index int) (T, error)
+
 	/*
 		Serializes the list.
 		If only compatible types are used, the output will be a valid JSON.
@@ -119,6 +302,65 @@ type List[T comparable] interface {
 	*/
 	String() string
 
+	/*
+		Serializes the list using custom delimiters.
+		Nested collections are serialized by their String method.
+
+		Parameters:
+		  - open - string inserted before the first element,
+		  - close - string inserted after the last element,
+		  - separator - string inserted between the elements.
+
+		Returns:
+		  - string representing serialized list.
+	*/
+	StringWithOptions(open string, close string, separator string) string
+
+	/*
+		Serializes the list strictly as a JSON array.
+		Each element is encoded by encoding/json, so the output is always a valid JSON.
+		Nested lists and dictionaries are encoded as arrays and objects.
+
+		Returns:
+		  - string representing the serialized list,
+		  - error if some element cannot be encoded (e.g. NaN, channel or function), nil otherwise.
+	*/
+	JSONString() (string, error)
+
+	/*
+		Serializes the list in a human-readable form.
+		Equivalent to StringOpts with spaces after commas and colons.
+
+		Returns:
+		  - string representing the serialized list.
+	*/
+	StringPretty() string
+
+	/*
+		Serializes the list with custom options, which are applied also to nested lists and dictionaries.
+		The output is a valid JSON unless some elements are left out by the MaxElements option.
+
+		Parameters:
+		  - opts - serialization options.
+
+		Returns:
+		  - string representing the serialized list.
+	*/
+	StringOpts(opts SerializeOptions) string
+
+	/*
+		Serializes only a given number of first elements of the list, followed by a number of the omitted ones
+		and the total count, e.g. [1,2,3,… +997 more] (count=1000).
+		Suitable for logging huge lists, the whole list is never serialized.
+
+		Parameters:
+		  - maxElements - maximal number of serialized elements (no limit if not positive).
+
+		Returns:
+		  - string summarizing the list.
+	*/
+	Summary(maxElements int) string
+
 	/*
 		Converts the list into a Go slice.
 		The slice is a reference.
@@ -136,6 +378,15 @@ type List[T comparable] interface {
 	*/
 	Clone() List[T]
 
+	/*
+		Creates a deep copy of the list.
+		Nested dictionaries and lists are copied recursively, other elements are copied the same way as by Clone.
+
+		Returns:
+		  - copied list.
+	*/
+	DeepClone() List[T]
+
 	/*
 		Gives a number of elements in the list.
 
@@ -154,7 +405,7 @@ type List[T comparable] interface {
 
 	/*
 		Checks if the content of the list is equal to the content of another list.
-		Nested dictionaries and lists are compared by reference.
+		Nested dictionaries and lists are compared by reference, a nil list is equal to an empty one.
 
 		Parameters:
 		  - another - a list to compare with.
@@ -165,21 +416,61 @@ type List[T comparable] interface {
 	Equals(another List[T]) bool
 
 	/*
-		Creates a new list containing all elements of the old list and another list.
+		Creates a new list containing all elements of the old list and other lists.
+		The new list never shares memory with any of the lists, so all of them remain unchanged.
+		Nil lists are treated as empty.
+
+		Parameters:
+		  - others... - any amount of lists to append.
+
+		Returns:
+		  - new list.
+	*/
+	Concat(others ...List[T]) List[T]
+
+	/*
+		Creates a new list consisting of a given number of copies of the list.
 		The old list remains unchanged.
+		Panics if the number of copies is negative.
 
 		Parameters:
-		  - another - a list to append.
+		  - count - number of copies.
+
+		Returns:
+		  - new list.
+	*/
+	Repeat(count int) List[T]
+
+	/*
+		Creates a new list containing the elements which are contained in exactly one of the two lists.
+		Elements of the list go first, followed by elements of the other list, both in their original order.
+		Each element is included once, even if it occurs more times. Both lists remain unchanged.
+
+		Parameters:
+		  - another - a list to compare with.
+
+		Returns:
+		  - new list.
+	*/
+	SymmetricDifference(another List[T]) List[T]
+
+	/*
+		Creates a new list with a separator inserted between every two neighbouring elements.
+		The old list remains unchanged.
+
+		Parameters:
+		  - sep - separator to insert.
 
 		Returns:
 		  - new list.
 	*/
-	Concat(another List[T]) List[T]
+	Intersperse(sep T) List[T]
 
 	/*
 		Creates a new list containing the elements from the starting index (including) to the ending index (excluding).
-		If the ending index is zero, it is set to the length of the list. If negative, it is counted from the end of the list.
-		Starting index has to be non-negative and cannot be higher than the ending index.
+		Negative indexes of both kinds are counted from the end of the list.
+		If the ending index is zero, it is set to the length of the list.
+		After the conversion, starting index cannot be higher than the ending index.
 
 		Parameters:
 		  - start - starting index,
@@ -190,6 +481,72 @@ type List[T comparable] interface {
 	*/
 	SubList(start int, end int) List[T]
 
+	/*
+		Creates a new list containing the elements from the starting index (including) to the ending index (excluding).
+		Unlike SubList, returns an error instead of panicking.
+
+		Parameters:
+		  - start - starting index,
+		  - end - ending index.
+
+		Returns:
+		  - created sub list (nil in case of an error),
+		  - ErrIndexOutOfRange if some of the indexes is out of range, ErrInvalidRange if the starting index is higher than the ending one,
+		    ErrNotInitialized if the list is not initialized, nil otherwise.
+	*/
+	SubListE(start int, end int) (List[T], error)
+
+	/*
+		Creates a new list containing the elements from the starting index (including) to the end of the list.
+		Negative starting index is counted from the end of the list.
+		Equivalent to SubList(start, 0).
+
+		Parameters:
+		  - start - starting index.
+
+		Returns:
+		  - created sub list.
+	*/
+	SubListFrom(start int) List[T]
+
+	/*
+		Creates a new list containing the elements from the beginning of the list to the ending index (excluding).
+		Negative ending index is counted from the end of the list.
+		Unlike SubList(0, end), zero ending index produces an empty list.
+
+		Parameters:
+		  - end - ending index.
+
+		Returns:
+		  - created sub list.
+	*/
+	SubListTo(end int) List[T]
+
+	/*
+		Splits the list into two new lists at a given position.
+		The old list remains unchanged and does not share memory with the new ones.
+		Panics if the index is out of range (it can be equal to the length of the list).
+
+		Parameters:
+		  - index - position of the first element of the second list.
+
+		Returns:
+		  - list of the elements before the position,
+		  - list of the elements from the position to the end.
+	*/
+	SplitAt(index int) (List[T], List[T])
+
+	/*
+		Splits the list into two new halves.
+		If the length of the list is odd, the second half is longer.
+		Equivalent to SplitAt(Count() / 2).
+
+		Returns:
+		  - first half of the list,
+		  - second half of the list.
+	*/
+	Halve() (List[T], List[T])
+
 	/*
 		Checks if the list contains a given element.
 		Dictionaries and lists are compared by reference.
@@ -213,14 +570,35 @@ type List[T comparable] interface {
 	*/
 	IndexOf(elem T) int
 
+	/*
+		Gives a number of occurrences of a given element.
+
+		Parameters:
+		  - elem - the element to count.
+
+		Returns:
+		  - number of occurrences (0 if the list does not contain the element).
+	*/
+	CountOf(elem T) int
+
 	/*
 		Reverses the order of elements in the list.
+		The list is reversed in place, the original order is not preserved.
 
 		Returns:
 		  - updated list.
 	*/
 	Reverse() List[T]
 
+	/*
+		Creates a new list containing the elements of the old one in the reverse order.
+		The old list remains unchanged.
+
+		Returns:
+		  - reversed list.
+	*/
+	ReverseClone() List[T]
+
 	/*
 		Executes a given function over an every element of the list.
 		The function has one parameter, the current element.
@@ -234,55 +612,328 @@ type List[T comparable] interface {
 	ForEach(function func(x T)) List[T]
 
 	/*
-		Copies the list and modifies each element by a given mapping function.
-		The resulting element has to be of a same type as the original one.
-		The function has one parameter, the current element.
-		The old list remains unchanged.
+		Executes a given function over an every element of the list.
+		The function has two parameters: index of the current element and its value.
 
 		Parameters:
 		  - function - anonymous function to be executed.
 
 		Returns:
-		  - new list.
+		  - unchanged list.
 	*/
-	Map(function func(x T) T) List[T]
+	ForEachIndexed(function func(i int, x T)) List[T]
 
 	/*
-		Reduces all elements of the list into a single value.
-		The result has to be of the same type as the elements of the list.
-		The function has two parameters: value returned by the previous iteration and value of the current element.
-		The old list remains unchanged.
+		Executes a given function over consecutive batches of elements of the list.
+		Each batch is a new list of a given size, only the last one can be smaller.
+		Panics if the size is not positive.
 
 		Parameters:
+		  - size - maximal number of elements in a batch,
 		  - function - anonymous function to be executed.
 
 		Returns:
-		  - computed value.
+		  - unchanged list.
 	*/
-	Reduce(initial T, function func(res T, x T) T) T
+	ForEachBatched(size int, function func(batch List[T])) List[T]
 
 	/*
-		Creates a new list containing elements of the old one satisfying a condition.
-		The function has one parameter, the current element, and returns bool.
-		The old list remains unchanged.
+		Executes a given function over an every element of the list for its side effects, e.g. logging.
+		Intended for an inspection of intermediate results in a chain of method calls.
+		The function has one parameter, the current element.
 
 		Parameters:
 		  - function - anonymous function to be executed.
 
 		Returns:
-		  - filtered list.
+		  - unchanged list.
 	*/
-	Filter(function func(x T) bool) List[T]
+	Tap(function func(x T)) List[T]
 
 	/*
-		Sorts the elements in the list (ascending).
-		Only lists of types string, int and float64 are sortable.
+		Executes a given function over an every element of the list concurrently.
+		Each element is processed in its own goroutine, so the order of the calls is not defined.
+		The function has one parameter, the current element, and returns an error.
+		Non-nil errors are sent to the returned channel, which is closed when all goroutines finish.
+		The list must not be modified until the channel is closed.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - channel of errors returned by the function.
+	*/
+	ForEachAsync(function func(x T) error) <-chan error
+
+	/*
+		Creates an iterator yielding the elements of the list over and over again.
+		The iteration never ends by itself, the caller has to break out of it.
+		An empty list yields nothing.
+
+		Returns:
+		  - infinite iterator over the elements.
+	*/
+	Cycle() iter.Seq[T]
+
+	/*
+		Copies the list and modifies each element by a given mapping function.
+		The resulting element has to be of a same type as the original one.
+		The function has one parameter, the current element.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	Map(function func(x T) T) List[T]
+
+	/*
+		Modifies each element of the list by a given mapping function.
+		Unlike Map, the list is modified in place, no new list is allocated.
+		The function has one parameter, the current element.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - updated list.
+	*/
+	MapInPlace(function func(x T) T) List[T]
+
+	/*
+		Copies the list and modifies each element by a given mapping function.
+		The resulting elements can be of any type, so the method can be used for a type change within a chain of method calls.
+		The function has one parameter, the current element.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - new list.
+	*/
+	MapAny(function func(x T) any) List[any]
+
+	/*
+		Copies the list and modifies each element by a given mapping function, concurrently.
+		The elements are split into contiguous parts processed by a given number of goroutines,
+		so the function has to be safe for concurrent use. The order of the elements is preserved.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed,
+		  - workers - number of goroutines (runtime.NumCPU() if not positive).
+
+		Returns:
+		  - new list.
+	*/
+	MapParallel(function func(x T) T, workers int) List[T]
+
+	/*
+		Reduces all elements of the list into a single value.
+		The result has to be of the same type as the elements of the list.
+		The function has two parameters: value returned by the previous iteration and value of the current element.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - computed value.
+	*/
+	Reduce(initial T, function func(res T, x T) T) T
+
+	/*
+		Reduces all elements of the list into a single value, using the first element as the initial value.
+		The function has two parameters: value returned by the previous iteration and value of the current element.
+		Panics if the list is empty.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - computed value.
+	*/
+	Fold(function func(res T, x T) T) T
+
+	/*
+		Creates a new list containing elements of the old one satisfying a condition.
+		The function has one parameter, the current element, and returns bool.
+		The old list remains unchanged.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - filtered list.
+	*/
+	Filter(function func(x T) bool) List[T]
+
+	/*
+		Passes the list to a given function and returns its result.
+		Allows to insert custom operations into a chain of method calls.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - list returned by the function.
+	*/
+	Pipe(function func(l List[T]) List[T]) List[T]
+
+	/*
+		Passes the whole list to a given function for its side effects, e.g. logging or metrics, and returns the list.
+		Unlike Tap, the function is called once. It receives the list itself, not a copy,
+		so its modifications are visible in the rest of a chain of method calls.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - the same list.
+	*/
+	Inspect(function func(l List[T])) List[T]
+
+	/*
+		Sorts the elements in the list (ascending).
+		The list is sorted in place, the original order is not preserved.
+		Only lists of types string, int and float64 are sortable.
+		Equal elements of these types are indistinguishable, so the sort does not need to be stable,
+		SortFunc is a stable sort by a custom comparison.
 
 		Returns:
 		  - updated list.
 	*/
 	Sort() List[T]
 
+	/*
+		Sorts the elements in the list by a given comparison function.
+		The function returns a negative number if a < b, a positive number if a > b and zero if they are equal.
+		The sort is stable, equal elements keep their original order,
+		so a list can be sorted by more keys by sorting it by the secondary key first and by the primary key then.
+
+		Parameters:
+		  - function - anonymous function comparing two elements.
+
+		Returns:
+		  - updated list.
+	*/
+	SortFunc(function func(a T, b T) int) List[T]
+
+	/*
+		Sorts the elements in the list (ascending) in place.
+		Same as Sort, the name just states the mutation explicitly.
+		Only lists of types string, int and float64 are sortable.
+
+		Returns:
+		  - updated list.
+	*/
+	SortInPlace() List[T]
+
+	/*
+		Creates a new list containing the elements of the old one sorted (ascending).
+		The old list remains unchanged.
+		Only lists of types string, int and float64 are sortable.
+
+		Returns:
+		  - sorted list.
+	*/
+	SortClone() List[T]
+
+	/*
+		Computes positions of the elements in the order in which they would be sorted (ascending).
+		The i-th element of the result is the index of the element which would be i-th after sorting,
+		equal elements keep their original order. The list remains unchanged.
+		Only lists of types string, int and float64 are sortable.
+
+		Returns:
+		  - list of indexes.
+	*/
+	SortIndices() List[int]
+
+	/*
+		Merges the list with another list into a new sorted list in linear time.
+		Both lists have to be sorted (ascending), equal elements of the list go before those of the other one.
+		Both lists remain unchanged.
+		Only lists of types string, int and float64 are sortable.
+
+		Parameters:
+		  - another - a sorted list to merge with.
+
+		Returns:
+		  - new sorted list.
+	*/
+	MergeSorted(another List[T]) List[T]
+
+	/*
+		Merges the list with another list into a new list in linear time, using a given comparison function.
+		Both lists have to be sorted by the function, equal elements of the list go before those of the other one.
+		Both lists remain unchanged.
+
+		Parameters:
+		  - another - a sorted list to merge with,
+		  - function - comparison function returning a negative number if a < b, zero if a == b and a positive number if a > b.
+
+		Returns:
+		  - new sorted list.
+	*/
+	MergeSortedFunc(another List[T], function func(a T, b T) int) List[T]
+
+	/*
+		Inserts a new element into a sorted list (ascending) at a position found by a binary search.
+		The element is inserted after the elements equal to it.
+		Only lists of types string, int and float64 are sortable.
+
+		Parameters:
+		  - value - new element.
+
+		Returns:
+		  - updated list.
+	*/
+	InsertSorted(value T) List[T]
+
+	/*
+		Computes a rank of each element, i.e. its position in the sorted list (starting from 0).
+		Equal elements share the lowest rank of their group, e.g. ranks of [10, 30, 10, 20] are [0, 3, 0, 2].
+		Only lists of types string, int and float64 are sortable.
+
+		Returns:
+		  - list of ranks.
+	*/
+	Ranks() List[int]
+
+	/*
+		Creates a new list with the elements reordered by a given permutation,
+		the i-th element of the result is the element at the i-th index of the permutation.
+		Combined with SortIndices of another list, it sorts parallel lists by the same key.
+		The old list remains unchanged.
+		Panics if the permutation has a different length than the list, or some index is out of range or repeated.
+
+		Parameters:
+		  - indices - permutation of the indexes of the list.
+
+		Returns:
+		  - reordered list.
+	*/
+	ReorderBy(indices List[int]) List[T]
+
+	/*
+		Creates a new list with the elements moved to new positions given by a mapping function,
+		the element at the i-th position of the old list is placed to the position returned for i.
+		The old list remains unchanged.
+		Panics if the function does not produce a permutation, i.e. some new position is out of range or repeated.
+
+		Parameters:
+		  - function - anonymous function mapping an old position to a new one.
+
+		Returns:
+		  - reordered list.
+	*/
+	Reindex(function func(oldIndex int) int) List[T]
+
 	/*
 		Finds a minimum of the list.
 		The list has to be either of type int or float64.
@@ -301,6 +952,26 @@ type List[T comparable] interface {
 	*/
 	Max() float64
 
+	/*
+		Finds a minimum of the list without converting it to float64.
+		The list has to be of type string, int or float64.
+
+		Returns:
+		  - found minimum, zero value if the list is empty,
+		  - true if the list is not empty, false otherwise.
+	*/
+	TryMin() (T, bool)
+
+	/*
+		Finds a maximum of the list without converting it to float64.
+		The list has to be of type string, int or float64.
+
+		Returns:
+		  - found maximum, zero value if the list is empty,
+		  - true if the list is not empty, false otherwise.
+	*/
+	TryMax() (T, bool)
+
 	/*
 		Computes a sum of the list.
 		The list has to be either of type int or float64.
@@ -310,6 +981,16 @@ type List[T comparable] interface {
 	*/
 	Sum() float64
 
+	/*
+		Computes a sum of the list in the type of its elements.
+		Unlike Sum, integers are summed in integer arithmetic, so large values do not lose precision.
+		The list has to be either of type int or float64.
+
+		Returns:
+		  - sum of the elements.
+	*/
+	SumTyped() T
+
 	/*
 		Computes a product of the list.
 		The list has to be either of type int or float64.
@@ -319,6 +1000,16 @@ type List[T comparable] interface {
 	*/
 	Prod() float64
 
+	/*
+		Computes a product of the list in the type of its elements.
+		Unlike Prod, integers are multiplied in integer arithmetic, so large values do not lose precision.
+		The list has to be either of type int or float64.
+
+		Returns:
+		  - product of the elements, 0 for an empty list (as Prod).
+	*/
+	ProdTyped() T
+
 	/*
 		Computes an avarage of the list.
 		The list has to be either of type int or float64.
@@ -327,10 +1018,147 @@ type List[T comparable] interface {
 		  - average of the elements.
 	*/
 	Avg() float64
+
+	/*
+		Computes an average of the list truncated to an integer.
+		Lists of type int are averaged in integer arithmetic, so large values do not lose precision.
+		The list has to be either of type int or float64.
+
+		Returns:
+		  - average of the elements truncated towards zero, 0 for an empty list.
+	*/
+	AvgInt() int
+
+	/*
+		Scales the list into the interval [0, 1], the minimum becomes 0 and the maximum becomes 1.
+		If all elements are equal, they are mapped to zeros.
+		The list has to be either of type int or float64, it remains unchanged.
+
+		Returns:
+		  - new list of normalized elements.
+	*/
+	Normalize() List[float64]
+
+	/*
+		Multiplies each element of the list by a given factor.
+		The list has to be either of type int or float64, it remains unchanged.
+
+		Parameters:
+		  - factor - number to multiply by.
+
+		Returns:
+		  - new list of products.
+	*/
+	Scale(factor float64) List[float64]
+
+	/*
+		Adds a given number to each element of the list.
+		The list has to be either of type int or float64, it remains unchanged.
+
+		Parameters:
+		  - x - number to add.
+
+		Returns:
+		  - new list of sums.
+	*/
+	AddScalar(x float64) List[float64]
+
+	/*
+		Adds elements of another list to the elements at the same positions.
+		Both lists have to be either of type int or float64 and of the same length, they remain unchanged.
+
+		Parameters:
+		  - another - list of addends.
+
+		Returns:
+		  - new list of sums.
+	*/
+	AddElementwise(another List[T]) List[float64]
+
+	/*
+		Subtracts elements of another list from the elements at the same positions.
+		Both lists have to be either of type int or float64 and of the same length, they remain unchanged.
+
+		Parameters:
+		  - another - list of subtrahends.
+
+		Returns:
+		  - new list of differences.
+	*/
+	SubElementwise(another List[T]) List[float64]
+
+	/*
+		Multiplies the elements by elements of another list at the same positions.
+		Both lists have to be either of type int or float64 and of the same length, they remain unchanged.
+
+		Parameters:
+		  - another - list of factors.
+
+		Returns:
+		  - new list of products.
+	*/
+	MulElementwise(another List[T]) List[float64]
+
+	/*
+		Divides the elements by elements of another list at the same positions.
+		Division by zero results in an infinity or NaN.
+		Both lists have to be either of type int or float64 and of the same length, they remain unchanged.
+
+		Parameters:
+		  - another - list of divisors.
+
+		Returns:
+		  - new list of quotients.
+	*/
+	DivElementwise(another List[T]) List[float64]
+
+	/*
+		Computes a dot product of the list and another list, i.e. a sum of products of the elements at the same positions.
+		Both lists have to be either of type int or float64 and of the same length.
+
+		Parameters:
+		  - another - second vector.
+
+		Returns:
+		  - dot product.
+	*/
+	DotProduct(another List[T]) float64
+
+	/*
+		Counts the elements in a given number of equal-width bins between the minimum and the maximum.
+		Each bin includes its lower edge, the last one includes also the maximum.
+		If all elements are equal, they are counted in the first bin.
+		NaN elements are not counted, the bins span only the finite elements and infinities are counted in the first or the last bin.
+		The list has to be either of type int or float64.
+		Panics if the number of bins is not positive.
+
+		Parameters:
+		  - bins - number of bins.
+
+		Returns:
+		  - deterministic dictionary of counts, indexed by bins from 0 in ascending order (empty bins included).
+	*/
+	Histogram(bins int) Dict[int, int]
+
+	/*
+		Counts the elements in bins with custom boundaries.
+		The i-th bin contains the elements from the i-th edge (inclusive) to the next one (exclusive),
+		the last bin includes also the final edge. Elements outside of the edges and NaN elements are not counted.
+		The list has to be either of type int or float64.
+		Panics if there are less than two edges or they are not strictly ascending.
+
+		Parameters:
+		  - edges - ascending boundaries of the bins.
+
+		Returns:
+		  - deterministic dictionary of counts, indexed by bins from 0 in ascending order (empty bins included).
+	*/
+	HistogramEdges(edges List[float64]) Dict[int, int]
 }
 
 /*
 sliceList, a reference type. Contains a slice of elements.
+Optionally validates elements stored into it.
 
 Implements:
   - Lister.
@@ -339,7 +1167,8 @@ Type parameters:
   - T - type of sliceList elements.
 */
 type sliceList[T comparable] struct {
-	val []T
+	val      []T
+	validate func(T) error
 }
 
 /*
@@ -363,65 +1192,246 @@ func NewList[T comparable](values ...T) List[T] {
 }
 
 /*
-List constructor.
-Creates a new list of n repeated values.
+List constructor.
+Creates a new empty list with a preallocated space for a given number of elements.
+
+Parameters:
+  - capacity - expected number of elements.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - pointer to the created list.
+*/
+func NewListWithCapacity[T comparable](capacity int) List[T] {
+	return &sliceList[T]{val: make([]T, 0, capacity)}
+}
+
+/*
+List constructor.
+Creates a new list of n repeated values.
+
+Parameters:
+  - value - value to repeat,
+  - count - number of repetitions.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - pointer to the created list.
+*/
+func NewListOf[T comparable](value T, count int) List[T] {
+	ego := sliceList[T]{val: make([]T, count)}
+	for i := 0; i < count; i++ {
+		ego.getVal()[i] = value
+	}
+	return &ego
+}
+
+/*
+List constructor.
+Creates a new validated list, which checks every element stored into it by a given function.
+Add, AddList, AddSlice, PrependList, Insert, InsertSorted, Replace, ReplaceAll, ReplaceWhere, Fill, PadLeft, PadRight, Resize and MapInPlace
+panic with the error returned by the validator. Adding methods reject all elements if some of them is invalid,
+MapInPlace stops at the first invalid element. AddE returns the error instead.
+Copies made by Clone and DeepClone are validated as well.
+
+Parameters:
+  - validate - function returning an error for an invalid element, nil otherwise.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - pointer to the created list.
+*/
+func NewValidatedList[T comparable](validate func(T) error) List[T] {
+	return &sliceList[T]{val: make([]T, 0), validate: validate}
+}
+
+/*
+List constructor.
+Converts a slice to a list.
+
+Parameters:
+  - slice - original slice.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - pointer to the created list.
+*/
+func NewListFrom[T comparable](goSlice []T) List[T] {
+	return &sliceList[T]{val: goSlice}
+}
+
+/*
+List constructor.
+Converts fields of a dictionary to a list of key-value pairs.
+The order of the pairs follows the iteration order of the dictionary.
+
+Parameters:
+  - dict - original dictionary.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+
+Returns:
+  - pointer to the created list.
+*/
+func NewListFromDict[K comparable, V comparable](dict Dict[K, V]) List[Pair[K, V]] {
+	list := &sliceList[Pair[K, V]]{val: make([]Pair[K, V], 0, dict.Count())}
+	dict.ForEach(func(key K, value V) {
+		list.val = append(list.val, Pair[K, V]{key, value})
+	})
+	return list
+}
+
+func (ego *sliceList[T]) serialize(b *strings.Builder) {
+	ego.serializeWithOptions(b, "[", "]", ",")
+}
+
+/*
+Writes the serialized list into a builder.
+
+Parameters:
+  - b - builder to write into,
+  - open - opening bracket,
+  - close - closing bracket,
+  - separator - separator of the elements.
+*/
+func (ego *sliceList[T]) serializeWithOptions(b *strings.Builder, open string, close string, separator string) {
+	b.Grow(len(open) + len(close) + (4+len(separator))*len(ego.getVal()))
+	b.WriteString(open)
+	for i, value := range ego.getVal() {
+		if i > 0 {
+			b.WriteString(separator)
+		}
+		writeString(b, value)
+	}
+	b.WriteString(close)
+}
+
+func (ego *sliceList[T]) getVal() []T {
+	if ego == nil {
+		return nil
+	}
+	return ego.val
+}
+
+/*
+Checks whether the list is initialized.
+
+Returns:
+  - ErrNotInitialized if the list is not initialized, nil otherwise.
+*/
+func (ego *sliceList[T]) checkInit() error {
+	if ego == nil || ego.getVal() == nil {
+		return errListNotInitialized
+	}
+	return nil
+}
+
+/*
+Checks whether a position is in range of the list.
 
 Parameters:
-  - value - value to repeat,
-  - count - number of repetitions.
-
-Type parameters:
-  - T - type of list elements.
+  - index - position to check.
 
 Returns:
-  - pointer to the created list.
+  - ErrIndexOutOfRange if the position is out of range, nil otherwise.
 */
-func NewListOf[T comparable](value T, count int) List[T] {
-	ego := sliceList[T]{make([]T, count)}
-	for i := 0; i < count; i++ {
-		ego.getVal()[i] = value
+func (ego *sliceList[T]) checkIndex(index int) error {
+	if index < 0 || index >= len(ego.getVal()) {
+		return ErrIndexOutOfRange{index, len(ego.getVal())}
 	}
-	return &ego
+	return nil
 }
 
 /*
-List constructor.
-Converts a slice to a list.
+Checks elements by the validator of the list.
 
 Parameters:
-  - slice - original slice.
-
-Type parameters:
-  - T - type of list elements.
+  - values... - elements to check.
 
 Returns:
-  - pointer to the created list.
+  - error returned by the validator for the first invalid element, nil if all elements are valid or the list is not validated.
 */
-func NewListFrom[T comparable](goSlice []T) List[T] {
-	return &sliceList[T]{goSlice}
+func (ego *sliceList[T]) checkValues(values ...T) error {
+	if ego.validate == nil {
+		return nil
+	}
+	for _, value := range values {
+		if err := ego.validate(value); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (ego *sliceList[T]) getVal() []T {
-	return ego.val
+/*
+Panics if some element is rejected by the validator of the list.
+
+Parameters:
+  - values... - elements to check.
+*/
+func (ego *sliceList[T]) valueCheck(values ...T) {
+	if err := ego.checkValues(values...); err != nil {
+		panic(err)
+	}
 }
 
 func (ego *sliceList[T]) assert() {
-	if ego == nil || ego.getVal() == nil {
-		panic("list is not initialized.")
+	if err := ego.checkInit(); err != nil {
+		panic(err)
 	}
 }
 
 func (ego *sliceList[T]) indexCheck(index int) {
-	if index < 0 || index >= ego.Count() {
-		panic(fmt.Sprintf("index %d out of range with count %d", index, ego.Count()))
+	if err := ego.checkIndex(index); err != nil {
+		panic(err)
 	}
 }
 
 func (ego *sliceList[T]) Add(values ...T) List[T] {
-	ego.assert()
-	for _, val := range values {
-		ego.val = append(ego.getVal(), val)
+	if err := ego.AddE(values...); err != nil {
+		panic(err)
+	}
+	return ego
+}
+
+func (ego *sliceList[T]) AddE(values ...T) error {
+	if err := ego.checkInit(); err != nil {
+		return err
+	}
+	if err := ego.checkValues(values...); err != nil {
+		return err
 	}
+	ego.val = append(ego.getVal(), values...)
+	return nil
+}
+
+func (ego *sliceList[T]) AddList(another List[T]) List[T] {
+	return ego.AddSlice(another.getVal())
+}
+
+func (ego *sliceList[T]) AddSlice(slice []T) List[T] {
+	ego.assert()
+	ego.valueCheck(slice...)
+	ego.val = append(ego.getVal(), slice...)
+	return ego
+}
+
+func (ego *sliceList[T]) PrependList(another List[T]) List[T] {
+	ego.assert()
+	ego.valueCheck(another.getVal()...)
+	result := make([]T, 0, len(another.getVal())+ego.Count())
+	result = append(result, another.getVal()...)
+	ego.val = append(result, ego.getVal()...)
 	return ego
 }
 
@@ -431,6 +1441,7 @@ func (ego *sliceList[T]) Insert(index int, value T) List[T] {
 		return ego.Add(value)
 	}
 	ego.indexCheck(index)
+	ego.valueCheck(value)
 	ego.val = append(ego.getVal()[:index+1], ego.getVal()[index:]...)
 	ego.getVal()[index] = value
 	return ego
@@ -439,32 +1450,83 @@ func (ego *sliceList[T]) Insert(index int, value T) List[T] {
 func (ego *sliceList[T]) Replace(index int, value T) List[T] {
 	ego.assert()
 	ego.indexCheck(index)
+	ego.valueCheck(value)
 	ego.getVal()[index] = value
 	return ego
 }
 
-func (ego *sliceList[T]) Delete(indexes ...int) List[T] {
+func (ego *sliceList[T]) ReplaceAll(old T, new T) List[T] {
+	return ego.ReplaceWhere(func(item T) bool { return item == old }, new)
+}
+
+func (ego *sliceList[T]) ReplaceWhere(function func(T) bool, new T) List[T] {
 	ego.assert()
+	checked := false
+	for i, item := range ego.val {
+		if function(item) {
+			if !checked {
+				ego.valueCheck(new)
+				checked = true
+			}
+			ego.val[i] = new
+		}
+	}
+	return ego
+}
+
+func (ego *sliceList[T]) ReplaceAllClone(old T, new T) List[T] {
+	return ego.Clone().ReplaceAll(old, new)
+}
+
+func (ego *sliceList[T]) Delete(indexes ...int) List[T] {
+	if err := ego.DeleteE(indexes...); err != nil {
+		panic(err)
+	}
+	return ego
+}
+
+func (ego *sliceList[T]) DeleteE(indexes ...int) error {
+	if err := ego.checkInit(); err != nil {
+		return err
+	}
+	for _, index := range indexes {
+		if err := ego.checkIndex(index); err != nil {
+			return err
+		}
+	}
 	if len(indexes) > 1 {
-		sort.Ints(indexes)
+		slices.Sort(indexes)
 	}
 	for i := len(indexes) - 1; i >= 0; i-- {
 		index := indexes[i]
-		ego.indexCheck(index)
+		if i+1 < len(indexes) && indexes[i+1] == index {
+			continue
+		}
 		ego.val = append(ego.getVal()[:index], ego.getVal()[index+1:]...)
 	}
-	return ego
+	return nil
 }
 
 func (ego *sliceList[T]) Pop() T {
+	elem, err := ego.PopE()
+	if err != nil {
+		panic(err)
+	}
+	return elem
+}
+
+func (ego *sliceList[T]) PopE() (T, error) {
+	var elem T
+	if err := ego.checkInit(); err != nil {
+		return elem, err
+	}
 	count := ego.Count()
 	if count == 0 {
-		panic("cannot pop from an empty list")
+		return elem, errPopEmpty
 	}
-	last := ego.Count() - 1
-	elem := ego.getVal()[last]
-	ego.Delete(last)
-	return elem
+	elem = ego.getVal()[count-1]
+	ego.val = ego.getVal()[:count-1]
+	return elem, nil
 }
 
 func (ego *sliceList[T]) Clear() List[T] {
@@ -473,22 +1535,302 @@ func (ego *sliceList[T]) Clear() List[T] {
 	return ego
 }
 
-func (ego *sliceList[T]) Get(index int) T {
+func (ego *sliceList[T]) Fill(value T) List[T] {
 	ego.assert()
-	ego.indexCheck(index)
-	return ego.getVal()[index]
+	if len(ego.val) > 0 {
+		ego.valueCheck(value)
+	}
+	for i := range ego.val {
+		ego.val[i] = value
+	}
+	return ego
+}
+
+func (ego *sliceList[T]) PadRight(length int, fill T) List[T] {
+	ego.assert()
+	if length > ego.Count() {
+		ego.valueCheck(fill)
+	}
+	for i := ego.Count(); i < length; i++ {
+		ego.val = append(ego.val, fill)
+	}
+	return ego
+}
+
+func (ego *sliceList[T]) PadLeft(length int, fill T) List[T] {
+	ego.assert()
+	if length <= ego.Count() {
+		return ego
+	}
+	ego.valueCheck(fill)
+	padded := make([]T, length)
+	missing := length - ego.Count()
+	for i := 0; i < missing; i++ {
+		padded[i] = fill
+	}
+	copy(padded[missing:], ego.val)
+	ego.val = padded
+	return ego
+}
+
+func (ego *sliceList[T]) Resize(length int, fill T) List[T] {
+	ego.assert()
+	if length < 0 {
+		panic(invalidArgument("length %d cannot be negative", length))
+	}
+	if length < ego.Count() {
+		// the dropped elements are cleared, so they do not stay referenced by the backing array
+		clear(ego.val[length:])
+		ego.val = ego.val[:length]
+		return ego
+	}
+	return ego.PadRight(length, fill)
+}
+
+func (ego *sliceList[T]) Get(index int) T {
+	elem, err := ego.GetE(index)
+	if err != nil {
+		panic(err)
+	}
+	return elem
+}
+
+func (ego *sliceList[T]) GetE(index int) (T, error) {
+	var elem T
+	if err := ego.checkIndex(index); err != nil {
+		return elem, err
+	}
+	return ego.getVal()[index], nil
 }
 
 func (ego *sliceList[T]) String() string {
-	result := "["
+	return ego.StringWithOptions("[", "]", ",")
+}
+
+func (ego *sliceList[T]) StringWithOptions(open string, close string, separator string) string {
+	var b strings.Builder
+	ego.serializeWithOptions(&b, open, close, separator)
+	return b.String()
+}
+
+func (ego *sliceList[T]) StringPretty() string {
+	return ego.StringOpts(SerializeOptions{Spaced: true})
+}
+
+func (ego *sliceList[T]) StringOpts(opts SerializeOptions) string {
+	var b strings.Builder
+	ego.serializeOptions(&b, opts, 0)
+	return b.String()
+}
+
+func (ego *sliceList[T]) serializeOptions(b *strings.Builder, opts SerializeOptions, depth int) {
+	b.WriteByte('[')
+	writeElements(b, opts, depth, len(ego.getVal()), func(i int) {
+		writeStringOptions(b, ego.val[i], opts, depth+1)
+	})
+	b.WriteByte(']')
+}
+
+func (ego *sliceList[T]) Summary(maxElements int) string {
+	count := ego.Count()
+	shown := count
+	if maxElements > 0 && maxElements < count {
+		shown = maxElements
+	}
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, value := range ego.getVal()[:shown] {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeString(&b, value)
+	}
+	writeSummaryEnd(&b, ']', shown, count)
+	return b.String()
+}
+
+/*
+Formats the list for the fmt package.
+Verbs %v and %s produce the output of String, %+v an indented form and %q a quoted String.
+Precision limits the number of written elements (as Summary does), width pads the output with spaces.
+
+Parameters:
+  - f - formatter state,
+  - verb - formatting verb.
+*/
+func (ego *sliceList[T]) Format(f fmt.State, verb rune) {
+	formatCollection(ego, f, verb)
+}
+
+func (ego *sliceList[T]) JSONString() (string, error) {
+	var b strings.Builder
+	if err := ego.writeJSON(&b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+/*
+Writes the list encoded as a JSON array into a builder.
+Elements are encoded by encoding/json, nested dictionaries which cannot be encoded that way
+are serialized by their String method and validated.
+
+Parameters:
+  - b - builder to write into.
+
+Returns:
+  - error if some element cannot be encoded, nil otherwise.
+*/
+func (ego *sliceList[T]) writeJSON(b *strings.Builder) error {
+	b.WriteByte('[')
 	for i, value := range ego.getVal() {
-		result += toString(value)
-		if i+1 < len(ego.getVal()) {
-			result += ","
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		var encoded []byte
+		var err error
+		switch val := any(value).(type) {
+		case json.Marshaler:
+			encoded, err = val.MarshalJSON()
+		case serializable:
+			encoded = []byte(val.String())
+			if !json.Valid(encoded) {
+				err = fmt.Errorf("element %d of type %T is not serializable to JSON", i, value)
+			}
+		default:
+			encoded, err = json.Marshal(value)
+		}
+		if err != nil {
+			return err
 		}
+		b.Write(encoded)
 	}
-	result += "]"
-	return result
+	b.WriteByte(']')
+	return nil
+}
+
+/*
+Encodes the list as a JSON array, so it can be nested in values passed to encoding/json.
+
+Returns:
+  - JSON array,
+  - error if some element cannot be encoded, nil otherwise.
+*/
+func (ego *sliceList[T]) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	if err := ego.writeJSON(&b); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
+
+/*
+Encodes the list as a JSON array, implements encoding.TextMarshaler.
+
+Returns:
+  - JSON array,
+  - error if some element cannot be encoded, nil otherwise.
+*/
+func (ego *sliceList[T]) MarshalText() ([]byte, error) {
+	return ego.MarshalJSON()
+}
+
+/*
+Replaces the elements of the list by a decoded JSON array, implements encoding.TextUnmarshaler.
+Nested objects and arrays are decoded as collections, see SQLList.
+
+Parameters:
+  - text - JSON array.
+
+Returns:
+  - error if the text is not a JSON array of elements convertible to the type of the list, nil otherwise.
+*/
+func (ego *sliceList[T]) UnmarshalText(text []byte) error {
+	value, err := parseJSON(text)
+	if err != nil {
+		return err
+	}
+	return ego.decode(value)
+}
+
+/*
+Converts the list into a slice of Go values for a YAML library, nested collections become Go maps and slices.
+The signature is compatible with gopkg.in/yaml.v2 and gopkg.in/yaml.v3 without depending on them.
+
+Returns:
+  - slice of elements,
+  - always nil.
+*/
+func (ego *sliceList[T]) MarshalYAML() (any, error) {
+	return ego.goValue(), nil
+}
+
+/*
+Replaces the elements of the list by a decoded YAML sequence.
+The signature is compatible with gopkg.in/yaml.v2 and gopkg.in/yaml.v3 without depending on them.
+
+Parameters:
+  - unmarshal - function decoding the YAML node into a given value, provided by the YAML library.
+
+Returns:
+  - error if the node is not a sequence of elements convertible to the type of the list, nil otherwise.
+*/
+func (ego *sliceList[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	var value any
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+	return ego.decode(fromNative(value))
+}
+
+/*
+Replaces the elements of the list by elements of a decoded list.
+The list is left unchanged if some element cannot be converted.
+
+Parameters:
+  - value - list produced by fromNative.
+
+Returns:
+  - error if the value is not a list or some element cannot be converted, nil otherwise.
+*/
+func (ego *sliceList[T]) decode(value any) error {
+	if ego == nil {
+		return errListNotInitialized
+	}
+	list, ok := value.(List[any])
+	if !ok {
+		return fmt.Errorf("cannot decode value of type %T into a list", value)
+	}
+	values := make([]T, list.Count())
+	for i, elem := range list.getVal() {
+		converted, err := convertValue[T](elem)
+		if err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+		values[i] = converted
+	}
+	if err := ego.checkValues(values...); err != nil {
+		return err
+	}
+	ego.val = values
+	return nil
+}
+
+/*
+Converts the list into a slice of Go values, nested collections become Go maps and slices.
+
+Returns:
+  - slice of elements, nil for a nil list.
+*/
+func (ego *sliceList[T]) goValue() any {
+	if ego == nil {
+		return nil
+	}
+	values := make([]any, len(ego.getVal()))
+	for i, value := range ego.getVal() {
+		values[i] = toNative(value)
+	}
+	return values
 }
 
 func (ego *sliceList[T]) GoSlice() []T {
@@ -498,11 +1840,23 @@ func (ego *sliceList[T]) GoSlice() []T {
 
 func (ego *sliceList[T]) Clone() List[T] {
 	ego.assert()
-	return NewList(ego.getVal()...)
+	return &sliceList[T]{val: append(make([]T, 0, ego.Count()), ego.getVal()...), validate: ego.validate}
 }
 
-func (ego *sliceList[T]) Count() int {
+func (ego *sliceList[T]) DeepClone() List[T] {
 	ego.assert()
+	list := &sliceList[T]{val: make([]T, ego.Count()), validate: ego.validate}
+	for i, value := range ego.getVal() {
+		list.getVal()[i] = deepCopy(value)
+	}
+	return list
+}
+
+func (ego *sliceList[T]) deepClone() any {
+	return ego.DeepClone()
+}
+
+func (ego *sliceList[T]) Count() int {
 	return len(ego.getVal())
 }
 
@@ -511,6 +1865,9 @@ func (ego *sliceList[T]) Empty() bool {
 }
 
 func (ego *sliceList[T]) Equals(another List[T]) bool {
+	if another == nil {
+		return ego.Count() == 0
+	}
 	if ego.Count() != another.Count() {
 		return false
 	}
@@ -522,32 +1879,130 @@ func (ego *sliceList[T]) Equals(another List[T]) bool {
 	return true
 }
 
-func (ego *sliceList[T]) Concat(another List[T]) List[T] {
+func (ego *sliceList[T]) Concat(others ...List[T]) List[T] {
 	ego.assert()
-	return &sliceList[T]{append(ego.getVal(), another.getVal()...)}
+	count := ego.Count()
+	for _, another := range others {
+		if another != nil {
+			count += len(another.getVal())
+		}
+	}
+	result := make([]T, 0, count)
+	result = append(result, ego.getVal()...)
+	for _, another := range others {
+		if another != nil {
+			result = append(result, another.getVal()...)
+		}
+	}
+	return &sliceList[T]{val: result}
 }
 
-func (ego *sliceList[T]) SubList(start int, end int) List[T] {
+func (ego *sliceList[T]) Repeat(count int) List[T] {
+	return RepeatList[T](ego, count)
+}
+
+func (ego *sliceList[T]) SymmetricDifference(another List[T]) List[T] {
+	ego.assert()
+	own := make(map[T]struct{}, ego.Count())
+	for _, item := range ego.val {
+		own[item] = struct{}{}
+	}
+	foreign := make(map[T]struct{}, another.Count())
+	for _, item := range another.getVal() {
+		foreign[item] = struct{}{}
+	}
+	result := make([]T, 0)
+	for _, item := range ego.val {
+		if _, ok := foreign[item]; !ok {
+			result = append(result, item)
+			foreign[item] = struct{}{}
+		}
+	}
+	for _, item := range another.getVal() {
+		if _, ok := own[item]; !ok {
+			result = append(result, item)
+			own[item] = struct{}{}
+		}
+	}
+	return &sliceList[T]{val: result}
+}
+
+func (ego *sliceList[T]) Intersperse(sep T) List[T] {
 	ego.assert()
+	if ego.Empty() {
+		return NewList[T]()
+	}
+	result := make([]T, 0, 2*ego.Count()-1)
+	for i, item := range ego.getVal() {
+		if i > 0 {
+			result = append(result, sep)
+		}
+		result = append(result, item)
+	}
+	return &sliceList[T]{val: result}
+}
+
+func (ego *sliceList[T]) SubList(start int, end int) List[T] {
+	list, err := ego.SubListE(start, end)
+	if err != nil {
+		panic(err)
+	}
+	return list
+}
+
+func (ego *sliceList[T]) SubListE(start int, end int) (List[T], error) {
+	if err := ego.checkInit(); err != nil {
+		return nil, err
+	}
+	if start > ego.Count() || start < -ego.Count() {
+		return nil, ErrIndexOutOfRange{start, ego.Count()}
+	}
 	if end > ego.Count() || end < -ego.Count() {
-		panic(fmt.Sprintf("ending index %d out of range with count %d", end, ego.Count()))
+		return nil, ErrIndexOutOfRange{end, ego.Count()}
+	}
+	if start < 0 {
+		start = ego.Count() + start
 	}
 	if end <= 0 {
 		end = ego.Count() + end
 	}
 	if start > end {
-		panic("starting index is higher than the ending index")
-	}
-	if start < 0 {
-		panic("starting index is lower than zero")
+		return nil, ErrInvalidRange{start, end}
 	}
-	list := &sliceList[T]{make([]T, end-start)}
+	list := &sliceList[T]{val: make([]T, end-start)}
 	copy(list.getVal(), ego.getVal()[start:end])
-	return list
+	return list, nil
+}
+
+func (ego *sliceList[T]) SubListFrom(start int) List[T] {
+	return ego.SubList(start, 0)
+}
+
+func (ego *sliceList[T]) SubListTo(end int) List[T] {
+	if end == 0 {
+		ego.assert()
+		return &sliceList[T]{val: make([]T, 0)}
+	}
+	return ego.SubList(0, end)
+}
+
+func (ego *sliceList[T]) SplitAt(index int) (List[T], List[T]) {
+	ego.assert()
+	if index < 0 || index > ego.Count() {
+		panic(ErrIndexOutOfRange{index, ego.Count()})
+	}
+	prefix := &sliceList[T]{val: make([]T, index)}
+	suffix := &sliceList[T]{val: make([]T, ego.Count()-index)}
+	copy(prefix.val, ego.val[:index])
+	copy(suffix.val, ego.val[index:])
+	return prefix, suffix
+}
+
+func (ego *sliceList[T]) Halve() (List[T], List[T]) {
+	return ego.SplitAt(ego.Count() / 2)
 }
 
 func (ego *sliceList[T]) Contains(elem T) bool {
-	ego.assert()
 	for _, item := range ego.getVal() {
 		if item == elem {
 			return true
@@ -557,7 +2012,6 @@ func (ego *sliceList[T]) Contains(elem T) bool {
 }
 
 func (ego *sliceList[T]) IndexOf(elem T) int {
-	ego.assert()
 	for i, item := range ego.getVal() {
 		if item == elem {
 			return i
@@ -566,6 +2020,16 @@ func (ego *sliceList[T]) IndexOf(elem T) int {
 	return -1
 }
 
+func (ego *sliceList[T]) CountOf(elem T) int {
+	count := 0
+	for _, item := range ego.getVal() {
+		if item == elem {
+			count++
+		}
+	}
+	return count
+}
+
 func (ego *sliceList[T]) Reverse() List[T] {
 	ego.assert()
 	for i := ego.Count()/2 - 1; i >= 0; i-- {
@@ -575,21 +2039,126 @@ func (ego *sliceList[T]) Reverse() List[T] {
 	return ego
 }
 
+func (ego *sliceList[T]) ReverseClone() List[T] {
+	return ego.Clone().Reverse()
+}
+
 func (ego *sliceList[T]) ForEach(function func(T)) List[T] {
-	ego.assert()
 	for _, item := range ego.getVal() {
 		function(item)
 	}
 	return ego
 }
 
-func (ego *sliceList[T]) Map(function func(T) T) List[T] {
+func (ego *sliceList[T]) ForEachIndexed(function func(int, T)) List[T] {
+	for i, item := range ego.getVal() {
+		function(i, item)
+	}
+	return ego
+}
+
+func (ego *sliceList[T]) ForEachBatched(size int, function func(List[T])) List[T] {
+	if size <= 0 {
+		panic(invalidArgument("batch size %d has to be positive", size))
+	}
+	for start := 0; start < ego.Count(); start += size {
+		end := start + size
+		if end > ego.Count() {
+			end = ego.Count()
+		}
+		batch := &sliceList[T]{val: make([]T, end-start)}
+		copy(batch.val, ego.val[start:end])
+		function(batch)
+	}
+	return ego
+}
+
+func (ego *sliceList[T]) Tap(function func(T)) List[T] {
+	return ego.ForEach(function)
+}
+
+func (ego *sliceList[T]) ForEachAsync(function func(T) error) <-chan error {
 	ego.assert()
-	result := NewList[T]()
+	errs := make(chan error, ego.Count())
+	var wg sync.WaitGroup
+	wg.Add(ego.Count())
 	for _, item := range ego.getVal() {
-		result.Add(function(item))
+		go func(item T) {
+			defer wg.Done()
+			if err := function(item); err != nil {
+				errs <- err
+			}
+		}(item)
+	}
+	go func() {
+		wg.Wait()
+		close(errs)
+	}()
+	return errs
+}
+
+func (ego *sliceList[T]) Cycle() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for !ego.Empty() {
+			for _, item := range ego.getVal() {
+				if !yield(item) {
+					return
+				}
+			}
+		}
 	}
-	return result
+}
+
+func (ego *sliceList[T]) Map(function func(T) T) List[T] {
+	ego.assert()
+	result := make([]T, ego.Count())
+	for i, item := range ego.getVal() {
+		result[i] = function(item)
+	}
+	return &sliceList[T]{val: result}
+}
+
+func (ego *sliceList[T]) MapInPlace(function func(T) T) List[T] {
+	ego.assert()
+	for i, item := range ego.val {
+		mapped := function(item)
+		ego.valueCheck(mapped)
+		ego.val[i] = mapped
+	}
+	return ego
+}
+
+func (ego *sliceList[T]) MapAny(function func(T) any) List[any] {
+	ego.assert()
+	result := make([]any, ego.Count())
+	for i, item := range ego.getVal() {
+		result[i] = function(item)
+	}
+	return &sliceList[any]{val: result}
+}
+
+func (ego *sliceList[T]) MapParallel(function func(T) T, workers int) List[T] {
+	ego.assert()
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > ego.Count() {
+		workers = ego.Count()
+	}
+	result := make([]T, ego.Count())
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		// each goroutine writes only to its own part of the result, so no locking is needed
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				result[i] = function(ego.val[i])
+			}
+		}(w*ego.Count()/workers, (w+1)*ego.Count()/workers)
+	}
+	wg.Wait()
+	return &sliceList[T]{val: result}
 }
 
 func (ego *sliceList[T]) Reduce(initial T, function func(T, T) T) T {
@@ -601,32 +2170,213 @@ func (ego *sliceList[T]) Reduce(initial T, function func(T, T) T) T {
 	return result
 }
 
+func (ego *sliceList[T]) Fold(function func(T, T) T) T {
+	ego.assert()
+	if ego.Empty() {
+		panic(ErrEmpty)
+	}
+	result := ego.getVal()[0]
+	for _, item := range ego.getVal()[1:] {
+		result = function(result, item)
+	}
+	return result
+}
+
 func (ego *sliceList[T]) Filter(function func(T) bool) List[T] {
 	ego.assert()
-	result := NewList[T]()
+	result := make([]T, 0, ego.Count())
 	for _, item := range ego.getVal() {
 		if function(item) {
-			result.Add(item)
+			result = append(result, item)
 		}
 	}
-	return result
+	// a sparse result would hold the whole source-sized array, so it is shrunk
+	if len(result) < cap(result)/4 {
+		result = append(make([]T, 0, len(result)), result...)
+	}
+	return &sliceList[T]{val: result}
+}
+
+func (ego *sliceList[T]) Pipe(function func(List[T]) List[T]) List[T] {
+	ego.assert()
+	return function(ego)
+}
+
+func (ego *sliceList[T]) Inspect(function func(List[T])) List[T] {
+	ego.assert()
+	function(ego)
+	return ego
 }
 
 func (ego *sliceList[T]) Sort() List[T] {
 	ego.assert()
 	switch val := any(ego.getVal()).(type) {
 	case []string:
-		sort.Strings(val)
+		slices.Sort(val)
 	case []int:
-		sort.Ints(val)
+		slices.Sort(val)
 	case []float64:
-		sort.Float64s(val)
+		slices.Sort(val)
 	default:
-		panic("unsortable list")
+		panic(ErrNotSortable)
+	}
+	return ego
+}
+
+func (ego *sliceList[T]) SortFunc(function func(T, T) int) List[T] {
+	ego.assert()
+	slices.SortStableFunc(ego.getVal(), function)
+	return ego
+}
+
+func (ego *sliceList[T]) SortInPlace() List[T] {
+	return ego.Sort()
+}
+
+func (ego *sliceList[T]) SortClone() List[T] {
+	return ego.Clone().Sort()
+}
+
+func (ego *sliceList[T]) MergeSorted(another List[T]) List[T] {
+	return ego.MergeSortedFunc(another, ego.comparator())
+}
+
+func (ego *sliceList[T]) MergeSortedFunc(another List[T], function func(T, T) int) List[T] {
+	ego.assert()
+	left, right := ego.val, another.getVal()
+	result := make([]T, 0, len(left)+len(right))
+	for len(left) > 0 && len(right) > 0 {
+		if function(left[0], right[0]) <= 0 {
+			result = append(result, left[0])
+			left = left[1:]
+		} else {
+			result = append(result, right[0])
+			right = right[1:]
+		}
 	}
+	result = append(result, left...)
+	result = append(result, right...)
+	return &sliceList[T]{val: result}
+}
+
+func (ego *sliceList[T]) InsertSorted(value T) List[T] {
+	ego.assert()
+	function := ego.comparator()
+	ego.valueCheck(value)
+	index, _ := slices.BinarySearchFunc(ego.val, value, func(item T, value T) int {
+		if function(item, value) <= 0 {
+			return -1
+		}
+		return 1
+	})
+	ego.val = slices.Insert(ego.val, index, value)
 	return ego
 }
 
+/*
+Acquires a natural comparison function of the list elements.
+Panics if the elements are not of type string, int or float64.
+
+Returns:
+  - comparison function.
+*/
+func (ego *sliceList[T]) comparator() func(T, T) int {
+	switch any(ego.val).(type) {
+	case []string:
+		return func(a, b T) int { return cmp.Compare(any(a).(string), any(b).(string)) }
+	case []int:
+		return func(a, b T) int { return cmp.Compare(any(a).(int), any(b).(int)) }
+	case []float64:
+		return func(a, b T) int { return cmp.Compare(any(a).(float64), any(b).(float64)) }
+	default:
+		panic(ErrNotSortable)
+	}
+}
+
+func (ego *sliceList[T]) SortIndices() List[int] {
+	ego.assert()
+	switch val := any(ego.getVal()).(type) {
+	case []string:
+		return &sliceList[int]{val: sortIndices(val)}
+	case []int:
+		return &sliceList[int]{val: sortIndices(val)}
+	case []float64:
+		return &sliceList[int]{val: sortIndices(val)}
+	default:
+		panic(ErrNotSortable)
+	}
+}
+
+func (ego *sliceList[T]) Ranks() List[int] {
+	indices := ego.SortIndices().getVal()
+	ranks := make([]int, len(indices))
+	for i, index := range indices {
+		if i > 0 && ego.val[index] == ego.val[indices[i-1]] {
+			ranks[index] = ranks[indices[i-1]]
+		} else {
+			ranks[index] = i
+		}
+	}
+	return &sliceList[int]{val: ranks}
+}
+
+func (ego *sliceList[T]) ReorderBy(indices List[int]) List[T] {
+	ego.assert()
+	if indices.Count() != ego.Count() {
+		panic(invalidArgument("permutation of length %d does not match the list of length %d", indices.Count(), ego.Count()))
+	}
+	result := make([]T, ego.Count())
+	used := make([]bool, ego.Count())
+	for i, index := range indices.getVal() {
+		ego.indexCheck(index)
+		if used[index] {
+			panic(invalidArgument("index %d occurs more than once in the permutation", index))
+		}
+		used[index] = true
+		result[i] = ego.val[index]
+	}
+	return &sliceList[T]{val: result}
+}
+
+func (ego *sliceList[T]) Reindex(function func(int) int) List[T] {
+	ego.assert()
+	result := make([]T, ego.Count())
+	used := make([]bool, ego.Count())
+	for i, item := range ego.val {
+		index := function(i)
+		ego.indexCheck(index)
+		if used[index] {
+			panic(invalidArgument("position %d is produced more than once by the mapping", index))
+		}
+		used[index] = true
+		result[index] = item
+	}
+	return &sliceList[T]{val: result}
+}
+
+/*
+Computes positions of values in the order in which they would be stably sorted.
+
+Parameters:
+  - values - values to sort.
+
+Type parameters:
+  - E - type of the values.
+
+Returns:
+  - slice of indexes.
+*/
+func sortIndices[E cmp.Ordered](values []E) []int {
+	indices := make([]int, len(values))
+	for i := range indices {
+		indices[i] = i
+	}
+	slices.SortStableFunc(indices, func(a, b int) int {
+		return cmp.Compare(values[a], values[b])
+	})
+	return indices
+}
+
 func (ego *sliceList[T]) Min() float64 {
 	min := math.MaxFloat64
 	switch val := any(ego.getVal()).(type) {
@@ -650,7 +2400,7 @@ func (ego *sliceList[T]) Min() float64 {
 			}
 		}
 	default:
-		panic("list type is neither int or float64")
+		panic(ErrNotNumeric)
 	}
 	return min
 }
@@ -678,11 +2428,31 @@ func (ego *sliceList[T]) Max() float64 {
 			}
 		}
 	default:
-		panic("list type is neither int or float64")
+		panic(ErrNotNumeric)
 	}
 	return max
 }
 
+func (ego *sliceList[T]) TryMin() (T, bool) {
+	ego.assert()
+	compare := ego.comparator()
+	if len(ego.getVal()) == 0 {
+		var zero T
+		return zero, false
+	}
+	return slices.MinFunc(ego.getVal(), compare), true
+}
+
+func (ego *sliceList[T]) TryMax() (T, bool) {
+	ego.assert()
+	compare := ego.comparator()
+	if len(ego.getVal()) == 0 {
+		var zero T
+		return zero, false
+	}
+	return slices.MaxFunc(ego.getVal(), compare), true
+}
+
 func (ego *sliceList[T]) Sum() float64 {
 	var sum float64
 	switch val := any(ego.getVal()).(type) {
@@ -701,7 +2471,7 @@ func (ego *sliceList[T]) Sum() float64 {
 			sum += item
 		}
 	default:
-		panic("list type is neither int or float64")
+		panic(ErrNotNumeric)
 	}
 	return sum
 }
@@ -724,11 +2494,238 @@ func (ego *sliceList[T]) Prod() float64 {
 			prod *= item
 		}
 	default:
-		panic("list type is neither int or float64")
+		panic(ErrNotNumeric)
 	}
 	return prod
 }
 
+func (ego *sliceList[T]) SumTyped() T {
+	switch val := any(ego.getVal()).(type) {
+	case []int:
+		sum := 0
+		for _, item := range val {
+			sum += item
+		}
+		return any(sum).(T)
+	case []float64:
+		return any(ego.Sum()).(T)
+	default:
+		panic(ErrNotNumeric)
+	}
+}
+
+func (ego *sliceList[T]) ProdTyped() T {
+	switch val := any(ego.getVal()).(type) {
+	case []int:
+		if len(val) == 0 {
+			return any(0).(T)
+		}
+		prod := 1
+		for _, item := range val {
+			prod *= item
+		}
+		return any(prod).(T)
+	case []float64:
+		return any(ego.Prod()).(T)
+	default:
+		panic(ErrNotNumeric)
+	}
+}
+
 func (ego *sliceList[T]) Avg() float64 {
 	return ego.Sum() / float64(ego.Count())
 }
+
+func (ego *sliceList[T]) AvgInt() int {
+	switch val := any(ego.getVal()).(type) {
+	case []int:
+		if len(val) == 0 {
+			return 0
+		}
+		sum := 0
+		for _, item := range val {
+			sum += item
+		}
+		return sum / len(val)
+	case []float64:
+		if len(val) == 0 {
+			return 0
+		}
+		return int(ego.Avg())
+	default:
+		panic(ErrNotNumeric)
+	}
+}
+
+/*
+Converts the elements of the list to floats.
+Panics if the list is neither of type int nor float64.
+
+Returns:
+  - new slice of floats.
+*/
+func (ego *sliceList[T]) floats() []float64 {
+	switch val := any(ego.getVal()).(type) {
+	case []int:
+		floats := make([]float64, len(val))
+		for i, item := range val {
+			floats[i] = float64(item)
+		}
+		return floats
+	case []float64:
+		return append(make([]float64, 0, len(val)), val...)
+	default:
+		panic(ErrNotNumeric)
+	}
+}
+
+/*
+Combines the elements of the list with the elements of another list at the same positions.
+Panics if the lists are not numeric or differ in length.
+
+Parameters:
+  - another - second list,
+  - function - function combining two elements.
+
+Returns:
+  - new list of results.
+*/
+func (ego *sliceList[T]) elementwise(another List[T], function func(a, b float64) float64) List[float64] {
+	if ego.Count() != another.Count() {
+		panic(invalidArgument("lists of lengths %d and %d cannot be combined element-wise", ego.Count(), another.Count()))
+	}
+	result := ego.floats()
+	for i, item := range (&sliceList[T]{val: another.getVal()}).floats() {
+		result[i] = function(result[i], item)
+	}
+	return &sliceList[float64]{val: result}
+}
+
+func (ego *sliceList[T]) Normalize() List[float64] {
+	result := ego.floats()
+	if len(result) == 0 {
+		return &sliceList[float64]{val: result}
+	}
+	min, max := ego.Min(), ego.Max()
+	for i, item := range result {
+		if max == min {
+			result[i] = 0
+		} else {
+			result[i] = (item - min) / (max - min)
+		}
+	}
+	return &sliceList[float64]{val: result}
+}
+
+func (ego *sliceList[T]) Scale(factor float64) List[float64] {
+	result := ego.floats()
+	for i := range result {
+		result[i] *= factor
+	}
+	return &sliceList[float64]{val: result}
+}
+
+func (ego *sliceList[T]) AddScalar(x float64) List[float64] {
+	result := ego.floats()
+	for i := range result {
+		result[i] += x
+	}
+	return &sliceList[float64]{val: result}
+}
+
+func (ego *sliceList[T]) AddElementwise(another List[T]) List[float64] {
+	return ego.elementwise(another, func(a, b float64) float64 { return a + b })
+}
+
+func (ego *sliceList[T]) SubElementwise(another List[T]) List[float64] {
+	return ego.elementwise(another, func(a, b float64) float64 { return a - b })
+}
+
+func (ego *sliceList[T]) MulElementwise(another List[T]) List[float64] {
+	return ego.elementwise(another, func(a, b float64) float64 { return a * b })
+}
+
+func (ego *sliceList[T]) DivElementwise(another List[T]) List[float64] {
+	return ego.elementwise(another, func(a, b float64) float64 { return a / b })
+}
+
+func (ego *sliceList[T]) DotProduct(another List[T]) float64 {
+	var product float64
+	for _, item := range ego.MulElementwise(another).getVal() {
+		product += item
+	}
+	return product
+}
+
+func (ego *sliceList[T]) Histogram(bins int) Dict[int, int] {
+	if bins <= 0 {
+		panic(invalidArgument("number of bins %d has to be positive", bins))
+	}
+	counts := make([]int, bins)
+	values := ego.floats()
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, item := range values {
+		if !math.IsNaN(item) && !math.IsInf(item, 0) {
+			low, high = math.Min(low, item), math.Max(high, item)
+		}
+	}
+	for _, item := range values {
+		bin := 0
+		switch {
+		case math.IsNaN(item):
+			continue
+		case math.IsInf(item, 1):
+			bin = bins - 1
+		case math.IsInf(item, -1):
+			bin = 0
+		case high > low:
+			bin = int((item - low) / (high - low) * float64(bins))
+		}
+		counts[max(0, min(bin, bins-1))]++
+	}
+	return histogramDict(counts)
+}
+
+func (ego *sliceList[T]) HistogramEdges(edges List[float64]) Dict[int, int] {
+	bounds := edges.getVal()
+	if len(bounds) < 2 {
+		panic(invalidArgument("at least 2 edges are needed, got %d", len(bounds)))
+	}
+	for i := 1; i < len(bounds); i++ {
+		if !(bounds[i] > bounds[i-1]) {
+			panic(invalidArgument("edge %v at index %d is not higher than the previous one", bounds[i], i))
+		}
+	}
+	counts := make([]int, len(bounds)-1)
+	for _, item := range ego.floats() {
+		if math.IsNaN(item) || item < bounds[0] || item > bounds[len(bounds)-1] {
+			continue
+		}
+		bin, found := slices.BinarySearch(bounds, item)
+		if !found {
+			bin--
+		}
+		if bin == len(counts) {
+			bin--
+		}
+		counts[bin]++
+	}
+	return histogramDict(counts)
+}
+
+/*
+Converts counts of bins to a deterministic dictionary.
+
+Parameters:
+  - counts - counts of the bins.
+
+Returns:
+  - dictionary of counts indexed by bins.
+*/
+func histogramDict(counts []int) Dict[int, int] {
+	result := NewDictDeterministic[int, int]()
+	for bin, count := range counts {
+		result.Set(bin, count)
+	}
+	return result
+}
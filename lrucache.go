@@ -0,0 +1,320 @@
+/*
+Collection Library for Go
+LRU cache type
+*/
+package collection
+
+import "strings"
+
+/*
+LRU cache, a dictionary with a maximum number of entries.
+When a new entry does not fit in, the least recently used one is evicted.
+Both reading by Get and writing by Set mark the entry as the most recently used.
+
+Type parameters:
+  - K - type of keys,
+  - V - type of values.
+*/
+type LRUCache[K comparable, V comparable] interface {
+	Collection
+
+	/*
+		Asserts that the cache is initialized.
+	*/
+	assert()
+
+	/*
+		Acquires the value under the specified key and marks the entry as the most recently used.
+
+		Parameters:
+		  - key - key of the entry to get.
+
+		Returns:
+		  - corresponding value (zero value if the key does not exist),
+		  - true if the key exists, false otherwise.
+	*/
+	Get(key K) (V, bool)
+
+	/*
+		Sets a value of an entry and marks it as the most recently used.
+		If the cache is full and the key does not exist yet, the least recently used entry is evicted.
+
+		Parameters:
+		  - key - key of the entry,
+		  - value - value to set.
+
+		Returns:
+		  - updated cache.
+	*/
+	Set(key K, value V) LRUCache[K, V]
+
+	/*
+		Removes an entry from the cache.
+		Missing keys are ignored.
+
+		Parameters:
+		  - key - key of the entry to remove.
+
+		Returns:
+		  - updated cache.
+	*/
+	Evict(key K) LRUCache[K, V]
+
+	/*
+		Removes all entries from the cache.
+
+		Returns:
+		  - updated cache.
+	*/
+	Clear() LRUCache[K, V]
+
+	/*
+		Serializes the cache from the most to the least recently used entry.
+		If only compatible types are used, the output will be a valid JSON.
+
+		Returns:
+		  - string representing the serialized cache.
+	*/
+	String() string
+
+	/*
+		Gives a number of entries in the cache.
+
+		Returns:
+		  - number of entries.
+	*/
+	Count() int
+
+	/*
+		Checks whether the cache is empty.
+
+		Returns:
+		  - true if the cache has no entries, false otherwise.
+	*/
+	Empty() bool
+
+	/*
+		Gives a maximum number of entries in the cache.
+
+		Returns:
+		  - capacity of the cache.
+	*/
+	Capacity() int
+
+	/*
+		Checks if a key exists in the cache.
+		Does not change the recency of the entry.
+
+		Parameters:
+		  - key - the key to check.
+
+		Returns:
+		  - true if the key exists, false otherwise.
+	*/
+	ContainsKey(key K) bool
+
+	/*
+		Acquires keys of the cache from the most to the least recently used entry.
+
+		Returns:
+		  - new list of keys.
+	*/
+	Keys() List[K]
+
+	/*
+		Acquires values of the cache from the most to the least recently used entry.
+
+		Returns:
+		  - new list of values.
+	*/
+	Values() List[V]
+}
+
+/*
+Entry of an LRU cache, a node of a doubly linked list ordered by recency.
+*/
+type lruNode[K comparable, V comparable] struct {
+	key   K
+	value V
+	prev  *lruNode[K, V]
+	next  *lruNode[K, V]
+}
+
+/*
+LRU cache, a reference type. Contains a dictionary of nodes for lookups and a circular doubly linked list of the same nodes for recency.
+The next node of the root is the most recently used entry, the previous one is the least recently used.
+
+Implements:
+  - LRUCache.
+
+Type parameters:
+  - K - type of keys,
+  - V - type of values.
+*/
+type lruCache[K comparable, V comparable] struct {
+	index    *mapDict[K, *lruNode[K, V]]
+	root     lruNode[K, V]
+	capacity int
+}
+
+/*
+LRU cache constructor.
+Creates a new empty cache.
+Panics if the capacity is not positive.
+
+Parameters:
+  - capacity - maximum number of entries.
+
+Type parameters:
+  - K - type of keys,
+  - V - type of values.
+
+Returns:
+  - pointer to the created cache.
+*/
+func NewLRUCache[K comparable, V comparable](capacity int) LRUCache[K, V] {
+	if capacity <= 0 {
+		panic(invalidArgument("capacity %d has to be positive", capacity))
+	}
+	ego := &lruCache[K, V]{
+		index:    &mapDict[K, *lruNode[K, V]]{val: make(map[K]*lruNode[K, V], capacity)},
+		capacity: capacity,
+	}
+	ego.root.prev, ego.root.next = &ego.root, &ego.root
+	return ego
+}
+
+/*
+Detaches a node from the recency list.
+
+Parameters:
+  - node - node to detach.
+*/
+func (ego *lruCache[K, V]) unlink(node *lruNode[K, V]) {
+	node.prev.next, node.next.prev = node.next, node.prev
+}
+
+/*
+Inserts a node to the front of the recency list, making it the most recently used.
+
+Parameters:
+  - node - node to insert.
+*/
+func (ego *lruCache[K, V]) pushFront(node *lruNode[K, V]) {
+	node.prev, node.next = &ego.root, ego.root.next
+	ego.root.next.prev = node
+	ego.root.next = node
+}
+
+/*
+Executes a given function over all entries from the most to the least recently used.
+
+Parameters:
+  - function - anonymous function to be executed.
+*/
+func (ego *lruCache[K, V]) each(function func(*lruNode[K, V])) {
+	for node := ego.root.next; node != &ego.root; node = node.next {
+		function(node)
+	}
+}
+
+func (ego *lruCache[K, V]) assert() {
+	if ego == nil || ego.index == nil {
+		panic(errLRUCacheNotInitialized)
+	}
+}
+
+func (ego *lruCache[K, V]) Get(key K) (V, bool) {
+	ego.assert()
+	node, ok := ego.index.val[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	ego.unlink(node)
+	ego.pushFront(node)
+	return node.value, true
+}
+
+func (ego *lruCache[K, V]) Set(key K, value V) LRUCache[K, V] {
+	ego.assert()
+	if node, ok := ego.index.val[key]; ok {
+		node.value = value
+		ego.unlink(node)
+		ego.pushFront(node)
+		return ego
+	}
+	if ego.index.Count() == ego.capacity {
+		oldest := ego.root.prev
+		ego.unlink(oldest)
+		ego.index.Unset(oldest.key)
+	}
+	node := &lruNode[K, V]{key: key, value: value}
+	ego.pushFront(node)
+	ego.index.Set(key, node)
+	return ego
+}
+
+func (ego *lruCache[K, V]) Evict(key K) LRUCache[K, V] {
+	ego.assert()
+	if node, ok := ego.index.val[key]; ok {
+		ego.unlink(node)
+		ego.index.Unset(key)
+	}
+	return ego
+}
+
+func (ego *lruCache[K, V]) Clear() LRUCache[K, V] {
+	ego.assert()
+	ego.index.Clear()
+	ego.root.prev, ego.root.next = &ego.root, &ego.root
+	return ego
+}
+
+func (ego *lruCache[K, V]) String() string {
+	ego.assert()
+	result := &mapDict[K, V]{val: make(map[K]V, ego.Count()), ordered: true, order: make([]K, 0, ego.Count())}
+	ego.each(func(node *lruNode[K, V]) {
+		result.Set(node.key, node.value)
+	})
+	var b strings.Builder
+	result.serialize(&b)
+	return b.String()
+}
+
+func (ego *lruCache[K, V]) Count() int {
+	ego.assert()
+	return ego.index.Count()
+}
+
+func (ego *lruCache[K, V]) Empty() bool {
+	return ego.Count() == 0
+}
+
+func (ego *lruCache[K, V]) Capacity() int {
+	ego.assert()
+	return ego.capacity
+}
+
+func (ego *lruCache[K, V]) ContainsKey(key K) bool {
+	ego.assert()
+	return ego.index.KeyExists(key)
+}
+
+func (ego *lruCache[K, V]) Keys() List[K] {
+	ego.assert()
+	keys := make([]K, 0, ego.Count())
+	ego.each(func(node *lruNode[K, V]) {
+		keys = append(keys, node.key)
+	})
+	return &sliceList[K]{val: keys}
+}
+
+func (ego *lruCache[K, V]) Values() List[V] {
+	ego.assert()
+	values := make([]V, 0, ego.Count())
+	ego.each(func(node *lruNode[K, V]) {
+		values = append(values, node.value)
+	})
+	return &sliceList[V]{val: values}
+}
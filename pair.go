@@ -0,0 +1,280 @@
+/*
+Collection Library for Go
+Pair, triple and entry types
+*/
+package collection
+
+import "strings"
+
+/*
+Pair, an ordered couple of values.
+It is a value type, so it is comparable and can be used as an element of a list or a key of a dictionary.
+
+Type parameters:
+  - A - type of the first value,
+  - B - type of the second value.
+*/
+type Pair[A comparable, B comparable] struct {
+	first  A
+	second B
+}
+
+/*
+Pair constructor.
+Creates a new pair.
+
+Parameters:
+  - first - first value,
+  - second - second value.
+
+Type parameters:
+  - A - type of the first value,
+  - B - type of the second value.
+
+Returns:
+  - created pair.
+*/
+func NewPair[A comparable, B comparable](first A, second B) Pair[A, B] {
+	return Pair[A, B]{first, second}
+}
+
+/*
+Splits a pair into its values.
+
+Parameters:
+  - pair - pair to split.
+
+Type parameters:
+  - A - type of the first value,
+  - B - type of the second value.
+
+Returns:
+  - first value,
+  - second value.
+*/
+func Unpair[A comparable, B comparable](pair Pair[A, B]) (A, B) {
+	return pair.first, pair.second
+}
+
+func (ego Pair[A, B]) serialize(b *strings.Builder) {
+	b.WriteByte('[')
+	writeString(b, ego.first)
+	b.WriteByte(',')
+	writeString(b, ego.second)
+	b.WriteByte(']')
+}
+
+/*
+Acquires the first value of the pair.
+
+Returns:
+  - first value.
+*/
+func (ego Pair[A, B]) First() A {
+	return ego.first
+}
+
+/*
+Acquires the second value of the pair.
+
+Returns:
+  - second value.
+*/
+func (ego Pair[A, B]) Second() B {
+	return ego.second
+}
+
+/*
+Creates a new pair with the values in the opposite order.
+
+Returns:
+  - swapped pair.
+*/
+func (ego Pair[A, B]) Swap() Pair[B, A] {
+	return Pair[B, A]{ego.second, ego.first}
+}
+
+/*
+Checks if the pair is equal to another pair.
+Nested dictionaries and lists are compared by reference.
+
+Parameters:
+  - another - a pair to compare with.
+
+Returns:
+  - true if the pairs are equal, false otherwise.
+*/
+func (ego Pair[A, B]) Equals(another Pair[A, B]) bool {
+	return ego == another
+}
+
+/*
+Serializes the pair as a two-element array.
+If only compatible types are used, the output will be a valid JSON.
+
+Returns:
+  - string representing the serialized pair.
+*/
+func (ego Pair[A, B]) String() string {
+	var b strings.Builder
+	ego.serialize(&b)
+	return b.String()
+}
+
+/*
+Triple, an ordered group of three values.
+It is a value type, so it is comparable and can be used as an element of a list or a key of a dictionary.
+
+Type parameters:
+  - A - type of the first value,
+  - B - type of the second value,
+  - C - type of the third value.
+*/
+type Triple[A comparable, B comparable, C comparable] struct {
+	first  A
+	second B
+	third  C
+}
+
+/*
+Triple constructor.
+Creates a new triple.
+
+Parameters:
+  - first - first value,
+  - second - second value,
+  - third - third value.
+
+Type parameters:
+  - A - type of the first value,
+  - B - type of the second value,
+  - C - type of the third value.
+
+Returns:
+  - created triple.
+*/
+func NewTriple[A comparable, B comparable, C comparable](first A, second B, third C) Triple[A, B, C] {
+	return Triple[A, B, C]{first, second, third}
+}
+
+/*
+Splits a triple into its values.
+
+Parameters:
+  - triple - triple to split.
+
+Type parameters:
+  - A - type of the first value,
+  - B - type of the second value,
+  - C - type of the third value.
+
+Returns:
+  - first value,
+  - second value,
+  - third value.
+*/
+func Untriple[A comparable, B comparable, C comparable](triple Triple[A, B, C]) (A, B, C) {
+	return triple.first, triple.second, triple.third
+}
+
+func (ego Triple[A, B, C]) serialize(b *strings.Builder) {
+	b.WriteByte('[')
+	writeString(b, ego.first)
+	b.WriteByte(',')
+	writeString(b, ego.second)
+	b.WriteByte(',')
+	writeString(b, ego.third)
+	b.WriteByte(']')
+}
+
+/*
+Acquires the first value of the triple.
+
+Returns:
+  - first value.
+*/
+func (ego Triple[A, B, C]) First() A {
+	return ego.first
+}
+
+/*
+Acquires the second value of the triple.
+
+Returns:
+  - second value.
+*/
+func (ego Triple[A, B, C]) Second() B {
+	return ego.second
+}
+
+/*
+Acquires the third value of the triple.
+
+Returns:
+  - third value.
+*/
+func (ego Triple[A, B, C]) Third() C {
+	return ego.third
+}
+
+/*
+Checks if the triple is equal to another triple.
+Nested dictionaries and lists are compared by reference.
+
+Parameters:
+  - another - a triple to compare with.
+
+Returns:
+  - true if the triples are equal, false otherwise.
+*/
+func (ego Triple[A, B, C]) Equals(another Triple[A, B, C]) bool {
+	return ego == another
+}
+
+/*
+Serializes the triple as a three-element array.
+If only compatible types are used, the output will be a valid JSON.
+
+Returns:
+  - string representing the serialized triple.
+*/
+func (ego Triple[A, B, C]) String() string {
+	var b strings.Builder
+	ego.serialize(&b)
+	return b.String()
+}
+
+/*
+Entry, a key-value pair of a dictionary.
+Unlike Pair, its fields are exported and named, so it can be created by a composite literal.
+It is a value type, so it is comparable and can be used as an element of a list.
+
+Type parameters:
+  - K - type of the key,
+  - V - type of the value.
+*/
+type Entry[K comparable, V comparable] struct {
+	Key   K
+	Value V
+}
+
+func (ego Entry[K, V]) serialize(b *strings.Builder) {
+	b.WriteString(`{"key":`)
+	writeString(b, ego.Key)
+	b.WriteString(`,"value":`)
+	writeString(b, ego.Value)
+	b.WriteByte('}')
+}
+
+/*
+Serializes the entry as an object with fields key and value.
+If only compatible types are used, the output will be a valid JSON.
+
+Returns:
+  - string representing the serialized entry.
+*/
+func (ego Entry[K, V]) String() string {
+	var b strings.Builder
+	ego.serialize(&b)
+	return b.String()
+}
@@ -0,0 +1,296 @@
+/*
+Collection Library for Go
+Set type
+*/
+package collection
+
+import "strings"
+
+/*
+Set, unordered collection of unique elements.
+
+Type parameters:
+  - T - type of set elements.
+*/
+type Set[T comparable] interface {
+	Collection
+
+	/*
+		Acquires the value of the set.
+
+		Returns:
+		  - inner map of the set.
+	*/
+	getVal() map[T]struct{}
+
+	/*
+		Asserts that the set is initialized.
+	*/
+	assert()
+
+	/*
+		Adds new elements to the set.
+		Elements which are already present are ignored.
+
+		Parameters:
+		  - values... - any amount of elements to add.
+
+		Returns:
+		  - updated set.
+	*/
+	Add(values ...T) Set[T]
+
+	/*
+		Removes the given elements from the set.
+		Panics if some of the elements is not present.
+
+		Parameters:
+		  - values... - any amount of elements to remove.
+
+		Returns:
+		  - updated set.
+	*/
+	Remove(values ...T) Set[T]
+
+	/*
+		Removes all elements from the set.
+
+		Returns:
+		  - updated set.
+	*/
+	Clear() Set[T]
+
+	/*
+		Serializes the set as an array.
+		If only compatible types are used, the output will be a valid JSON.
+
+		Returns:
+		  - string representing the serialized set.
+	*/
+	String() string
+
+	/*
+		Converts the set to a list of its elements.
+		The order of the elements is not defined.
+
+		Returns:
+		  - list of elements of the set.
+	*/
+	ToList() List[T]
+
+	/*
+		Creates a copy of the set.
+
+		Returns:
+		  - copied set.
+	*/
+	Clone() Set[T]
+
+	/*
+		Gives a number of elements in the set.
+
+		Returns:
+		  - number of elements.
+	*/
+	Count() int
+
+	/*
+		Checks whether the set is empty.
+
+		Returns:
+		  - true if the set has no elements, false otherwise.
+	*/
+	Empty() bool
+
+	/*
+		Checks if the set contains the same elements as another set.
+
+		Parameters:
+		  - another - a set to compare with.
+
+		Returns:
+		  - true if the sets are equal, false otherwise.
+	*/
+	Equals(another Set[T]) bool
+
+	/*
+		Checks if the set contains a given element.
+
+		Parameters:
+		  - elem - the element to check.
+
+		Returns:
+		  - true if the set contains the element, false otherwise.
+	*/
+	Contains(elem T) bool
+
+	/*
+		Executes a given function over an every element of the set.
+		The function has one parameter, the current element.
+
+		Parameters:
+		  - function - anonymous function to be executed.
+
+		Returns:
+		  - unchanged set.
+	*/
+	ForEach(function func(x T)) Set[T]
+}
+
+/*
+Set, a reference type. Contains a map with elements as keys.
+
+Implements:
+  - Set.
+
+Type parameters:
+  - T - type of set elements.
+*/
+type mapSet[T comparable] struct {
+	val map[T]struct{}
+}
+
+/*
+Set constructor.
+Creates a new set.
+
+Parameters:
+  - values... - any amount of initial elements, duplicates are ignored.
+
+Type parameters:
+  - T - type of set elements.
+
+Returns:
+  - pointer to the created set.
+*/
+func NewSet[T comparable](values ...T) Set[T] {
+	ego := &mapSet[T]{make(map[T]struct{}, len(values))}
+	ego.Add(values...)
+	return ego
+}
+
+/*
+Converts a list to a set.
+Duplicate elements of the list are stored once.
+
+Parameters:
+  - list - list to convert.
+
+Type parameters:
+  - T - type of list elements.
+
+Returns:
+  - new set.
+*/
+func ToSet[T comparable](list List[T]) Set[T] {
+	list.assert()
+	return NewSet(list.getVal()...)
+}
+
+func (ego *mapSet[T]) serialize(b *strings.Builder) {
+	b.Grow(4 * len(ego.getVal()))
+	b.WriteByte('[')
+	first := true
+	for value := range ego.getVal() {
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		writeString(b, value)
+	}
+	b.WriteByte(']')
+}
+
+func (ego *mapSet[T]) getVal() map[T]struct{} {
+	return ego.val
+}
+
+func (ego *mapSet[T]) assert() {
+	if ego == nil || ego.getVal() == nil {
+		panic(errSetNotInitialized)
+	}
+}
+
+func (ego *mapSet[T]) Add(values ...T) Set[T] {
+	ego.assert()
+	for _, value := range values {
+		ego.getVal()[value] = struct{}{}
+	}
+	return ego
+}
+
+func (ego *mapSet[T]) Remove(values ...T) Set[T] {
+	ego.assert()
+	for _, value := range values {
+		if !ego.Contains(value) {
+			panic(ErrValueNotFound{value})
+		}
+		delete(ego.getVal(), value)
+	}
+	return ego
+}
+
+func (ego *mapSet[T]) Clear() Set[T] {
+	ego.assert()
+	ego.val = make(map[T]struct{})
+	return ego
+}
+
+func (ego *mapSet[T]) String() string {
+	var b strings.Builder
+	ego.serialize(&b)
+	return b.String()
+}
+
+func (ego *mapSet[T]) ToList() List[T] {
+	ego.assert()
+	values := make([]T, 0, len(ego.getVal()))
+	for value := range ego.getVal() {
+		values = append(values, value)
+	}
+	return &sliceList[T]{val: values}
+}
+
+func (ego *mapSet[T]) Clone() Set[T] {
+	ego.assert()
+	result := &mapSet[T]{make(map[T]struct{}, len(ego.getVal()))}
+	for value := range ego.getVal() {
+		result.getVal()[value] = struct{}{}
+	}
+	return result
+}
+
+func (ego *mapSet[T]) Count() int {
+	ego.assert()
+	return len(ego.getVal())
+}
+
+func (ego *mapSet[T]) Empty() bool {
+	return ego.Count() == 0
+}
+
+func (ego *mapSet[T]) Equals(another Set[T]) bool {
+	if ego.Count() != another.Count() {
+		return false
+	}
+	for value := range ego.getVal() {
+		if _, ok := another.getVal()[value]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (ego *mapSet[T]) Contains(elem T) bool {
+	ego.assert()
+	_, ok := ego.getVal()[elem]
+	return ok
+}
+
+func (ego *mapSet[T]) ForEach(function func(T)) Set[T] {
+	ego.assert()
+	for value := range ego.getVal() {
+		function(value)
+	}
+	return ego
+}
@@ -0,0 +1,149 @@
+/*
+Collection Library for Go
+SQL adapters
+*/
+package collection
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+/*
+Dictionary which can be stored in a SQL database as a JSON object, e.g. in a jsonb column.
+Implements driver.Valuer and sql.Scanner, nested objects and arrays are scanned as collections.
+A nil dictionary is stored as NULL, NULL is scanned as an empty dictionary.
+*/
+type SQLDict struct {
+	Dict[string, any]
+}
+
+/*
+Encodes the dictionary for a database driver.
+
+Returns:
+  - JSON object as a byte slice, nil for a nil dictionary,
+  - error if the dictionary is not serializable to JSON, nil otherwise.
+*/
+func (ego SQLDict) Value() (driver.Value, error) {
+	if ego.Dict == nil || ego.Dict.getVal() == nil {
+		return nil, nil
+	}
+	encoded := []byte(ego.Dict.String())
+	if !json.Valid(encoded) {
+		return nil, errors.New("dictionary is not serializable to JSON")
+	}
+	return encoded, nil
+}
+
+/*
+Decodes the dictionary from a database value.
+
+Parameters:
+  - src - JSON object as a byte slice or a string, or nil for NULL.
+
+Returns:
+  - error if the value is not a JSON object, nil otherwise.
+*/
+func (ego *SQLDict) Scan(src any) error {
+	data, err := scanned(src)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		ego.Dict = NewDict[string, any]()
+		return nil
+	}
+	value, err := parseJSON(data)
+	if err != nil {
+		return err
+	}
+	dict, ok := value.(Dict[string, any])
+	if !ok {
+		return fmt.Errorf("cannot scan JSON value of type %T into a dictionary", value)
+	}
+	ego.Dict = dict
+	return nil
+}
+
+/*
+List which can be stored in a SQL database as a JSON array, e.g. in a jsonb column.
+Implements driver.Valuer and sql.Scanner, nested objects and arrays are scanned as collections.
+A nil list is stored as NULL, NULL is scanned as an empty list.
+*/
+type SQLList struct {
+	List[any]
+}
+
+/*
+Encodes the list for a database driver.
+
+Returns:
+  - JSON array as a byte slice, nil for a nil list,
+  - error if some element cannot be encoded, nil otherwise.
+*/
+func (ego SQLList) Value() (driver.Value, error) {
+	if ego.List == nil || ego.List.getVal() == nil {
+		return nil, nil
+	}
+	encoded, err := ego.List.JSONString()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(encoded), nil
+}
+
+/*
+Decodes the list from a database value.
+
+Parameters:
+  - src - JSON array as a byte slice or a string, or nil for NULL.
+
+Returns:
+  - error if the value is not a JSON array, nil otherwise.
+*/
+func (ego *SQLList) Scan(src any) error {
+	data, err := scanned(src)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		ego.List = NewList[any]()
+		return nil
+	}
+	value, err := parseJSON(data)
+	if err != nil {
+		return err
+	}
+	list, ok := value.(List[any])
+	if !ok {
+		return fmt.Errorf("cannot scan JSON value of type %T into a list", value)
+	}
+	ego.List = list
+	return nil
+}
+
+/*
+Acquires JSON data from a value passed to sql.Scanner.
+
+Parameters:
+  - src - scanned value.
+
+Returns:
+  - JSON data, nil for NULL,
+  - error if the value is neither a byte slice nor a string, nil otherwise.
+*/
+func scanned(src any) ([]byte, error) {
+	switch val := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return val, nil
+	case string:
+		return []byte(val), nil
+	default:
+		return nil, fmt.Errorf("cannot scan value of type %T as JSON", src)
+	}
+}
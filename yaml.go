@@ -0,0 +1,94 @@
+/*
+Collection Library for Go
+YAML adapters
+*/
+package collection
+
+/*
+List which can be a field of a YAML-tagged struct.
+Fields of interface types cannot be decoded by YAML libraries, so the list is wrapped in a struct
+implementing the marshaler interfaces of gopkg.in/yaml.v2 and gopkg.in/yaml.v3 without depending on them.
+A nil list is encoded as null, decoding always creates a new list.
+
+Type parameters:
+  - T - type of list elements.
+*/
+type YAMLList[T comparable] struct {
+	List[T]
+}
+
+/*
+Converts the list into a slice of Go values, nested collections become Go maps and slices.
+
+Returns:
+  - slice of elements, nil for a nil list,
+  - always nil.
+*/
+func (ego YAMLList[T]) MarshalYAML() (any, error) {
+	return toNative(ego.List), nil
+}
+
+/*
+Replaces the list by a decoded YAML sequence.
+
+Parameters:
+  - unmarshal - function decoding the YAML node into a given value, provided by the YAML library.
+
+Returns:
+  - error if the node is not a sequence of elements convertible to the type of the list, nil otherwise.
+*/
+func (ego *YAMLList[T]) UnmarshalYAML(unmarshal func(any) error) error {
+	list := &sliceList[T]{}
+	if err := list.UnmarshalYAML(unmarshal); err != nil {
+		return err
+	}
+	ego.List = list
+	return nil
+}
+
+/*
+Dictionary which can be a field of a YAML-tagged struct.
+Fields of interface types cannot be decoded by YAML libraries, so the dictionary is wrapped in a struct
+implementing the marshaler interfaces of gopkg.in/yaml.v2 and gopkg.in/yaml.v3 without depending on them.
+A nil dictionary is encoded as null, decoding creates a new dictionary unless a deterministic one is present.
+
+Type parameters:
+  - K - type of dictionary keys,
+  - V - type of dictionary values.
+*/
+type YAMLDict[K comparable, V comparable] struct {
+	Dict[K, V]
+}
+
+/*
+Converts the dictionary into a Go map, nested collections become Go maps and slices.
+
+Returns:
+  - map of fields, nil for a nil dictionary,
+  - always nil.
+*/
+func (ego YAMLDict[K, V]) MarshalYAML() (any, error) {
+	return toNative(ego.Dict), nil
+}
+
+/*
+Replaces the fields of the dictionary by a decoded YAML mapping.
+An existing deterministic dictionary stays deterministic, its fields are ordered by keys.
+
+Parameters:
+  - unmarshal - function decoding the YAML node into a given value, provided by the YAML library.
+
+Returns:
+  - error if the node is not a mapping with keys and values convertible to the types of the dictionary, nil otherwise.
+*/
+func (ego *YAMLDict[K, V]) UnmarshalYAML(unmarshal func(any) error) error {
+	dict, ok := ego.Dict.(*mapDict[K, V])
+	if !ok || dict == nil {
+		dict = &mapDict[K, V]{val: make(map[K]V)}
+	}
+	if err := dict.UnmarshalYAML(unmarshal); err != nil {
+		return err
+	}
+	ego.Dict = dict
+	return nil
+}